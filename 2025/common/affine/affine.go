@@ -0,0 +1,105 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package affine implements affine transforms modulo m (x → a·x + b mod m),
+// the algebra behind linear congruential generators and card shuffles. These
+// compose into another transform of the same shape, which means a sequence of
+// them can be collapsed into one and then repeated an enormous number of times
+// via exponentiation by squaring.
+package affine
+
+import (
+	"fmt"
+
+	"github.com/simmonmt/aoc/2025/common/mtsmath"
+)
+
+// AffineMod maps x to A·x + B mod M. A and B are always kept in [0, M).
+type AffineMod struct {
+	A, B, M int64
+}
+
+// New returns x → a·x + b mod m. m must be positive.
+func New(a, b, m int64) AffineMod {
+	if m <= 0 {
+		panic("bad modulus")
+	}
+	return AffineMod{A: mtsmath.Mod(a, m), B: mtsmath.Mod(b, m), M: m}
+}
+
+// Identity returns x → x mod m.
+func Identity(m int64) AffineMod {
+	return New(1, 0, m)
+}
+
+func (f AffineMod) String() string {
+	return fmt.Sprintf("x → %d·x + %d mod %d", f.A, f.B, f.M)
+}
+
+// Apply returns f(x).
+func (f AffineMod) Apply(x int64) int64 {
+	return mtsmath.AddMod(mtsmath.MulMod(f.A, x, f.M), f.B, f.M)
+}
+
+// Then returns the transform that applies f followed by g (i.e. g∘f).
+func (f AffineMod) Then(g AffineMod) AffineMod {
+	if f.M != g.M {
+		panic("modulus mismatch")
+	}
+
+	// g(f(x)) = gA·(fA·x + fB) + gB = gA·fA·x + gA·fB + gB
+	return AffineMod{
+		A: mtsmath.MulMod(g.A, f.A, f.M),
+		B: mtsmath.AddMod(mtsmath.MulMod(g.A, f.B, f.M), g.B, f.M),
+		M: f.M,
+	}
+}
+
+// Inverse returns the transform that undoes f, or false if A isn't coprime to
+// M (in which case f isn't a bijection).
+func (f AffineMod) Inverse() (AffineMod, bool) {
+	aInv, ok := mtsmath.ModInverse(f.A, f.M)
+	if !ok {
+		return AffineMod{}, false
+	}
+
+	// y = A·x + B → x = A⁻¹·y - A⁻¹·B
+	return AffineMod{
+		A: aInv,
+		B: mtsmath.Mod(-mtsmath.MulMod(aInv, f.B, f.M), f.M),
+		M: f.M,
+	}, true
+}
+
+// Pow returns f applied n times. Negative n applies the inverse -n times, and
+// panics if f has no inverse.
+func (f AffineMod) Pow(n int64) AffineMod {
+	if n < 0 {
+		inv, ok := f.Inverse()
+		if !ok {
+			panic("not invertible")
+		}
+		// -n would overflow for MinInt64, so peel off one step.
+		return inv.Then(inv.Pow(-(n + 1)))
+	}
+
+	out := Identity(f.M)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			out = out.Then(f)
+		}
+		f = f.Then(f)
+	}
+	return out
+}
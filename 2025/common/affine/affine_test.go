@@ -0,0 +1,145 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package affine
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAffineMod(t *testing.T) {
+	f := New(3, 4, 11)
+	g := New(-2, 7, 11)
+
+	if got, want := f.Apply(5), int64(8); got != want {
+		t.Errorf("f(5) = %v, want %v", got, want)
+	}
+
+	fg := f.Then(g)
+	for x := int64(0); x < 11; x++ {
+		if got, want := fg.Apply(x), g.Apply(f.Apply(x)); got != want {
+			t.Errorf("f.Then(g)(%v) = %v, want %v", x, got, want)
+		}
+	}
+
+	inv, ok := f.Inverse()
+	if !ok {
+		t.Fatalf("f.Inverse() failed")
+	}
+	for x := int64(0); x < 11; x++ {
+		if got := inv.Apply(f.Apply(x)); got != x {
+			t.Errorf("inv(f(%v)) = %v", x, got)
+		}
+	}
+
+	if _, ok := New(2, 1, 10).Inverse(); ok {
+		t.Errorf("Inverse of non-coprime A = _, true, want false")
+	}
+
+	// A·x + B overflows int64 when M is this big.
+	const m = math.MaxInt64 - 24
+	h := New(1, m-1, m)
+	if got, want := h.Apply(m-1), int64(m-2); got != want {
+		t.Errorf("h(m-1) = %v, want %v", got, want)
+	}
+	if got, want := h.Then(h).B, int64(m-2); got != want {
+		t.Errorf("h.Then(h).B = %v, want %v", got, want)
+	}
+}
+
+func TestPow(t *testing.T) {
+	f := New(7, 3, 101)
+
+	x := int64(5)
+	for n := int64(0); n < 50; n++ {
+		if got := f.Pow(n).Apply(5); got != x {
+			t.Errorf("f^%v(5) = %v, want %v", n, got, x)
+		}
+		if got := f.Pow(-n).Apply(x); got != 5 {
+			t.Errorf("f^-%v(%v) = %v, want 5", n, x, got)
+		}
+		x = f.Apply(x)
+	}
+
+	// Large modulus and exponent: MulMod must keep this from overflowing,
+	// and forward then backward must round trip.
+	const m = 119315717514047
+	const n = 101741582076661
+	big := New(48271, 12345, m)
+	if got := big.Pow(-n).Apply(big.Pow(n).Apply(2020)); got != 2020 {
+		t.Errorf("f^-n(f^n(2020)) = %v, want 2020", got)
+	}
+}
+
+// shuffleDeck applies a position transform to a deck, returning the deck in
+// its new order.
+func shuffleDeck(f AffineMod) []int {
+	out := make([]int, f.M)
+	for card := int64(0); card < f.M; card++ {
+		out[f.Apply(card)] = int(card)
+	}
+	return out
+}
+
+func TestParseShuffles(t *testing.T) {
+	type TestCase struct {
+		cmds string
+		want []int
+	}
+
+	testCases := []TestCase{
+		TestCase{"deal into new stack", []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+		TestCase{"cut 3", []int{3, 4, 5, 6, 7, 8, 9, 0, 1, 2}},
+		TestCase{"cut -4", []int{6, 7, 8, 9, 0, 1, 2, 3, 4, 5}},
+		TestCase{"deal with increment 3", []int{0, 7, 4, 1, 8, 5, 2, 9, 6, 3}},
+		TestCase{
+			"deal with increment 7\ndeal into new stack\ndeal into new stack",
+			[]int{0, 3, 6, 9, 2, 5, 8, 1, 4, 7},
+		},
+		TestCase{
+			"cut 6\ndeal with increment 7\ndeal into new stack",
+			[]int{3, 0, 7, 4, 1, 8, 5, 2, 9, 6},
+		},
+		TestCase{
+			"deal with increment 7\ndeal with increment 9\ncut -2",
+			[]int{6, 3, 0, 7, 4, 1, 8, 5, 2, 9},
+		},
+		TestCase{
+			"deal into new stack\ncut -2\ndeal with increment 7\ncut 8\n" +
+				"cut -4\ndeal with increment 7\ncut 3\n" +
+				"deal with increment 9\ndeal with increment 3\ncut -1",
+			[]int{9, 2, 5, 8, 1, 4, 7, 0, 3, 6},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			f, err := ParseShuffles(strings.Split(tc.cmds, "\n"), 10)
+			if err != nil {
+				t.Fatalf("ParseShuffles = %v", err)
+			}
+			if got := shuffleDeck(f); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("shuffled = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := ParseShuffles([]string{"cut 1", "shuffle"}, 10); err == nil {
+		t.Errorf("ParseShuffles(bad) = _, nil, want error")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package affine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseShuffle parses one of the three card shuffle commands from 2019/22 into
+// the transform that maps a card's position before the shuffle to its position
+// after, for a deck of size m:
+//
+//	deal into new stack       x → -x - 1
+//	cut N                     x → x - N
+//	deal with increment N     x → N·x
+func ParseShuffle(str string, m int64) (AffineMod, error) {
+	switch {
+	case str == "deal into new stack":
+		return New(-1, -1, m), nil
+
+	case strings.HasPrefix(str, "cut "):
+		n, err := strconv.ParseInt(strings.TrimPrefix(str, "cut "), 10, 64)
+		if err != nil {
+			return AffineMod{}, fmt.Errorf("bad cut: %v", err)
+		}
+		return New(1, -n, m), nil
+
+	case strings.HasPrefix(str, "deal with increment "):
+		n, err := strconv.ParseInt(strings.TrimPrefix(str, "deal with increment "), 10, 64)
+		if err != nil {
+			return AffineMod{}, fmt.Errorf("bad increment: %v", err)
+		}
+		return New(n, 0, m), nil
+
+	default:
+		return AffineMod{}, fmt.Errorf("unknown shuffle %q", str)
+	}
+}
+
+// ParseShuffles parses a sequence of shuffle commands (see ParseShuffle) and
+// combines them into a single transform.
+func ParseShuffles(lines []string, m int64) (AffineMod, error) {
+	out := Identity(m)
+	for i, line := range lines {
+		f, err := ParseShuffle(line, m)
+		if err != nil {
+			return AffineMod{}, fmt.Errorf("%d: %v", i+1, err)
+		}
+		out = out.Then(f)
+	}
+	return out, nil
+}
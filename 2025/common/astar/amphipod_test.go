@@ -0,0 +1,342 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astar
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// amphipodClient models 2021/23. A state is the 11 hallway cells followed by
+// each room's cells, top first.
+type amphipodClient struct {
+	depth int
+}
+
+var amphipodEnergy = map[byte]uint{'A': 1, 'B': 10, 'C': 100, 'D': 1000}
+
+func parseAmphipods(lines []string) (string, *amphipodClient) {
+	depth := len(lines) - 3
+	rooms := make([]byte, 4*depth)
+	for r := 0; r < 4; r++ {
+		for i := 0; i < depth; i++ {
+			rooms[r*depth+i] = lines[2+i][3+2*r]
+		}
+	}
+	return lines[1][1:12] + string(rooms), &amphipodClient{depth: depth}
+}
+
+func (c *amphipodClient) goal() string {
+	out := strings.Repeat(".", 11)
+	for r := 0; r < 4; r++ {
+		out += strings.Repeat(string(rune('A'+r)), c.depth)
+	}
+	return out
+}
+
+// coords returns the x (hallway column) and y (0 for the hallway, 1+ for
+// room slots) of cell idx.
+func (c *amphipodClient) coords(idx int) (x, y int) {
+	if idx < 11 {
+		return idx, 0
+	}
+	r, i := (idx-11)/c.depth, (idx-11)%c.depth
+	return 2 + 2*r, i + 1
+}
+
+func (c *amphipodClient) room(s string, r int) string {
+	return s[11+r*c.depth : 11+(r+1)*c.depth]
+}
+
+// roomClean returns true if room r holds only its own kind.
+func (c *amphipodClient) roomClean(s string, r int) bool {
+	return strings.Trim(c.room(s, r), "."+string(rune('A'+r))) == ""
+}
+
+func hallClear(s string, from, to int) bool {
+	lo, hi := min(from, to), max(from, to)
+	return strings.Trim(s[lo:hi+1], ".") == ""
+}
+
+func move(s string, from, to int) string {
+	b := []byte(s)
+	b[from], b[to] = '.', s[from]
+	return string(b)
+}
+
+// amphipodStops are the hallway cells amphipods can stop in: all but the ones
+// outside rooms.
+var amphipodStops = []int{0, 1, 3, 5, 7, 9, 10}
+
+func (c *amphipodClient) AllNeighbors(s string) []string {
+	out := []string{}
+
+	for h := 0; h < 11; h++ {
+		if s[h] == '.' {
+			continue
+		}
+		r := int(s[h] - 'A')
+		entrance := 2 + 2*r
+		step := 1
+		if entrance < h {
+			step = -1
+		}
+		if !c.roomClean(s, r) || !hallClear(s, h+step, entrance) {
+			continue
+		}
+		slot := strings.LastIndexByte(c.room(s, r), '.')
+		out = append(out, move(s, h, 11+r*c.depth+slot))
+	}
+
+	for r := 0; r < 4; r++ {
+		if c.roomClean(s, r) {
+			continue
+		}
+		slot := strings.IndexFunc(c.room(s, r), func(c rune) bool { return c != '.' })
+		from := 11 + r*c.depth + slot
+		for _, h := range amphipodStops {
+			if hallClear(s, 2+2*r, h) {
+				out = append(out, move(s, from, h))
+			}
+		}
+	}
+	return out
+}
+
+// AllPredecessors undoes the moves AllNeighbors makes, for Bidirectional.
+func (c *amphipodClient) AllPredecessors(s string) []string {
+	out := []string{}
+
+	// Amphipods at the top of their own clean room may have just arrived
+	// from the hallway.
+	for r := 0; r < 4; r++ {
+		slot := strings.IndexFunc(c.room(s, r), func(c rune) bool { return c != '.' })
+		if slot < 0 || !c.roomClean(s, r) {
+			continue
+		}
+		for _, h := range amphipodStops {
+			if hallClear(s, 2+2*r, h) {
+				out = append(out, move(s, 11+r*c.depth+slot, h))
+			}
+		}
+	}
+
+	// Amphipods in the hallway may have just left a room that wasn't clean
+	// with them in it.
+	for h := 0; h < 11; h++ {
+		if s[h] == '.' {
+			continue
+		}
+		for r := 0; r < 4; r++ {
+			entrance := 2 + 2*r
+			step := 1
+			if h < entrance {
+				step = -1
+			}
+			slot := strings.LastIndexByte(c.room(s, r), '.')
+			if slot < 0 || !hallClear(s, entrance, h-step) {
+				continue
+			}
+			if int(s[h]-'A') == r && c.roomClean(s, r) {
+				continue
+			}
+			out = append(out, move(s, h, 11+r*c.depth+slot))
+		}
+	}
+	return out
+}
+
+func (c *amphipodClient) NeighborDistance(n1, n2 string) uint {
+	from, to := -1, -1
+	for i := range n1 {
+		if n1[i] != n2[i] {
+			if n1[i] == '.' {
+				to = i
+			} else {
+				from = i
+			}
+		}
+	}
+	x1, y1 := c.coords(from)
+	x2, y2 := c.coords(to)
+	return uint(max(x1-x2, x2-x1)+y1+y2) * amphipodEnergy[n1[from]]
+}
+
+func (c *amphipodClient) EstimateDistance(start, end string) uint {
+	est := uint(0)
+	for i := range start {
+		if start[i] == '.' {
+			continue
+		}
+		x, y := c.coords(i)
+		tx := 2 + 2*int(start[i]-'A')
+		if x == tx && y > 0 {
+			continue
+		}
+		est += uint(max(x-tx, tx-x)+y+1) * amphipodEnergy[start[i]]
+	}
+	return est
+}
+
+func (c *amphipodClient) GoalReached(cand, goal string) bool {
+	return cand == goal
+}
+
+func (c *amphipodClient) Serialize(s string) string {
+	return s
+}
+
+func (c *amphipodClient) Deserialize(s string) (string, error) {
+	return s, nil
+}
+
+var (
+	amphipodSample = []string{
+		"#############",
+		"#...........#",
+		"###B#C#B#D###",
+		"  #A#D#C#A#",
+		"  #########",
+	}
+
+	amphipodUnfolded = []string{
+		"#############",
+		"#...........#",
+		"###B#C#B#D###",
+		"  #D#C#B#A#",
+		"  #D#B#A#C#",
+		"  #A#D#C#A#",
+		"  #########",
+	}
+)
+
+func pathCost[T any](path []T, client ClientInterface[T]) uint {
+	cost := uint(0)
+	for i := 1; i < len(path); i++ {
+		cost += client.NeighborDistance(path[i], path[i-1])
+	}
+	return cost
+}
+
+func TestAmphipodPredecessors(t *testing.T) {
+	start, client := parseAmphipods(amphipodSample)
+
+	// Check every state within a few moves of the start, both ways.
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 && len(seen) < 5000 {
+		s := queue[0]
+		queue = queue[1:]
+
+		for _, n := range client.AllNeighbors(s) {
+			if !slices.Contains(client.AllPredecessors(n), s) {
+				t.Fatalf("%v -> %v, but %v isn't a predecessor", s, n, s)
+			}
+			if !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+		for _, p := range client.AllPredecessors(s) {
+			if !slices.Contains(client.AllNeighbors(p), s) {
+				t.Fatalf("%v is a predecessor of %v, but not its neighbor", p, s)
+			}
+		}
+	}
+}
+
+func TestAmphipod(t *testing.T) {
+	start, client := parseAmphipods(amphipodSample)
+	goal := client.goal()
+
+	ida := NewIDAStar(start, goal, client)
+	ida.SetMaxCacheEntries(1 << 20)
+	if got := pathCost(ida.Solve(), client); got != 12521 {
+		t.Errorf("IDAStar cost = %v, want 12521", got)
+	}
+
+	if got := pathCost(NewBidirectional(start, goal, client).Solve(), client); got != 12521 {
+		t.Errorf("Bidirectional cost = %v, want 12521", got)
+	}
+}
+
+type solver[T any] interface {
+	Solve() []T
+}
+
+func benchmarkSolver[T any](b *testing.B, newSolver func() solver[T], client ClientInterface[T], want uint) {
+	for i := 0; i < b.N; i++ {
+		path := newSolver().Solve()
+		if got := pathCost(path, client); got != want {
+			b.Fatalf("cost = %v, want %v", got, want)
+		}
+	}
+}
+
+func BenchmarkHelper(b *testing.B) {
+	helper := newTestHelper()
+	type solverCase struct {
+		name string
+		new  func() solver[string]
+	}
+	for _, sc := range []solverCase{
+		{"AStar", func() solver[string] { return New("start", "end", helper) }},
+		{"IDAStar", func() solver[string] { return NewIDAStar("start", "end", helper) }},
+		{"Bidirectional", func() solver[string] { return NewBidirectional("start", "end", helper) }},
+	} {
+		b.Run(sc.name, func(b *testing.B) { benchmarkSolver(b, sc.new, helper, 45) })
+	}
+}
+
+// BenchmarkAmphipod compares the solvers on 2021/23. Amphipod moves aren't
+// reversible, so Bidirectional searches backward using AllPredecessors.
+// IDAStar without a transposition table is only run on the sample: it
+// re-explores the many routes to each state, and takes minutes on the
+// unfolded puzzle.
+func BenchmarkAmphipod(b *testing.B) {
+	type puzzleCase struct {
+		name  string
+		lines []string
+		want  uint
+	}
+
+	for _, pc := range []puzzleCase{
+		{"Sample", amphipodSample, 12521},
+		{"Unfolded", amphipodUnfolded, 44169},
+	} {
+		start, client := parseAmphipods(pc.lines)
+		goal := client.goal()
+
+		b.Run(pc.name+"/AStar", func(b *testing.B) {
+			benchmarkSolver(b, func() solver[string] { return New(start, goal, client) }, client, pc.want)
+		})
+		b.Run(pc.name+"/IDAStar", func(b *testing.B) {
+			benchmarkSolver(b, func() solver[string] {
+				s := NewIDAStar(start, goal, client)
+				s.SetMaxCacheEntries(1 << 20)
+				return s
+			}, client, pc.want)
+		})
+		if pc.name == "Sample" {
+			b.Run(pc.name+"/IDAStarNoCache", func(b *testing.B) {
+				benchmarkSolver(b, func() solver[string] { return NewIDAStar(start, goal, client) }, client, pc.want)
+			})
+		}
+		b.Run(pc.name+"/Bidirectional", func(b *testing.B) {
+			benchmarkSolver(b, func() solver[string] { return NewBidirectional(start, goal, client) }, client, pc.want)
+		})
+	}
+}
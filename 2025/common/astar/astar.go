@@ -106,8 +106,7 @@ func (a *AStar[T]) Solve() []T {
 		panic("reuse")
 	}
 
-	startStr, goalStr := a.client.Serialize(a.start), a.client.Serialize(a.goal)
-	logger.Infof("astar start %v goal %v", startStr, goalStr)
+	startStr := a.client.Serialize(a.start)
 
 	a.openSet = collections.NewPriorityQueue[string](collections.LessThan)
 	a.cameFrom = map[string]string{}
@@ -117,13 +116,16 @@ func (a *AStar[T]) Solve() []T {
 
 	startEstimate := a.client.EstimateDistance(a.start, a.goal)
 	a.openSet.Insert(startStr, int(startEstimate))
+	openSize := 1
 
-	for round := 0; !a.openSet.IsEmpty() && (a.numRounds < 0 || round < a.numRounds); round++ {
-		logger.Infof("===round %v", round)
-		logger.Infof("open set %v", a.openSet)
-		logger.Infof("gScore %+v", a.gScore)
+	progress := logger.NewProgress("astar", "expanded")
+	progress.Gauge("open", func() int64 { return int64(openSize) })
+	progress.Gauge("seen", func() int64 { return int64(len(a.gScore)) })
+	defer progress.Done()
 
+	for round := 0; !a.openSet.IsEmpty() && (a.numRounds < 0 || round < a.numRounds); round++ {
 		currentStr, _ := a.openSet.Next()
+		openSize--
 		current, err := a.client.Deserialize(currentStr)
 		if err != nil {
 			panic("bad current")
@@ -135,21 +137,14 @@ func (a *AStar[T]) Solve() []T {
 
 		currentGScore := a.gScore.Get(currentStr)
 
-		neighbors := a.client.AllNeighbors(current)
-		neighborStrs := make([]string, len(neighbors))
-		for i, neighbor := range neighbors {
-			neighborStrs[i] = a.client.Serialize(neighbor)
-		}
-
-		logger.Infof("neighbors of %v: %v", currentStr, neighborStrs)
-		for i, neighbor := range neighbors {
-			neighborStr := neighborStrs[i]
+		progress.Add(1)
+		for _, neighbor := range a.client.AllNeighbors(current) {
+			neighborStr := a.client.Serialize(neighbor)
 
 			neighborGScore := currentGScore +
 				a.client.NeighborDistance(current, neighbor)
 
 			if neighborGScore >= a.gScore.Get(neighborStr) {
-				logger.Infof("%v to %v isn't better", currentStr, neighborStr)
 				continue // not a better path
 			}
 
@@ -158,7 +153,9 @@ func (a *AStar[T]) Solve() []T {
 			a.gScore[neighborStr] = neighborGScore
 
 			neighborFScore := neighborGScore + a.client.EstimateDistance(neighbor, a.goal)
-			a.openSet.Insert(neighborStr, int(neighborFScore))
+			if a.openSet.Insert(neighborStr, int(neighborFScore)) {
+				openSize++
+			}
 		}
 	}
 
@@ -113,6 +113,68 @@ func TestAStar(t *testing.T) {
 	}
 }
 
+// newTestHelper returns the graph from TestAStar, for the other solvers.
+func newTestHelper() *aStarHelper {
+	return &aStarHelper{
+		nodes: map[string]helperNode{
+			"start": helperNode{distances: map[string]uint{"a": 15, "d": 20}},
+			"a":     helperNode{distances: map[string]uint{"start": 15, "b": 20}},
+			"b":     helperNode{distances: map[string]uint{"a": 20, "c": 30}},
+			"c":     helperNode{distances: map[string]uint{"b": 30, "end": 40}},
+			"d":     helperNode{distances: map[string]uint{"start": 20, "d1": 2, "e": 20}},
+			"d1":    helperNode{distances: map[string]uint{"d": 2, "d2": 1}},
+			"d2":    helperNode{distances: map[string]uint{"d1": 1, "d3": 1}},
+			"d3":    helperNode{distances: map[string]uint{"d2": 1, "e": 1}},
+			"e":     helperNode{distances: map[string]uint{"d": 20, "d3": 1, "end": 20}},
+			"end":   helperNode{distances: map[string]uint{"c": 40, "e": 20}},
+		},
+	}
+}
+
+func TestIDAStar(t *testing.T) {
+	solver := NewIDAStar("start", "end", newTestHelper())
+	result := solver.Solve()
+
+	expected := []string{"end", "e", "d3", "d2", "d1", "d", "start"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("start->end, got %v, want %v", result, expected)
+	}
+
+	if result := NewIDAStar("start", "nowhere", newTestHelper()).Solve(); result != nil {
+		t.Errorf("start->nowhere, got %v, want nil", result)
+	}
+
+	// A free path to the goal is optimal as soon as it's found.
+	free := &aStarHelper{
+		nodes: map[string]helperNode{
+			"start": helperNode{distances: map[string]uint{"a": 0, "end": 5}},
+			"a":     helperNode{distances: map[string]uint{"start": 0, "end": 0}},
+			"end":   helperNode{distances: map[string]uint{"start": 5, "a": 0}},
+		},
+	}
+	expected = []string{"end", "a", "start"}
+	if result := NewIDAStar("start", "end", free).Solve(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("free start->end, got %v, want %v", result, expected)
+	}
+}
+
+func TestBidirectional(t *testing.T) {
+	solver := NewBidirectional("start", "end", newTestHelper())
+	result := solver.Solve()
+
+	expected := []string{"end", "e", "d3", "d2", "d1", "d", "start"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("start->end, got %v, want %v", result, expected)
+	}
+
+	if result := NewBidirectional("start", "start", newTestHelper()).Solve(); !reflect.DeepEqual(result, []string{"start"}) {
+		t.Errorf("start->start, got %v, want [start]", result)
+	}
+	if result := NewBidirectional("start", "nowhere", newTestHelper()).Solve(); result != nil {
+		t.Errorf("start->nowhere, got %v, want nil", result)
+	}
+}
+
 func TestMain(m *testing.M) {
 	flag.Parse()
 	logger.Init(true)
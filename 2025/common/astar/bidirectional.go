@@ -0,0 +1,174 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astar
+
+import (
+	"math"
+
+	"github.com/simmonmt/aoc/2025/common/collections"
+	"github.com/simmonmt/aoc/2025/common/logger"
+)
+
+// Bidirectional is a bidirectional Dijkstra search, which grows one search
+// outward from the start and another backward from the goal, stopping once
+// they provably can't find a shorter meeting point. With unit costs it's a
+// bidirectional BFS. On graphs that branch a lot it visits far fewer states
+// than a one-sided search.
+//
+// The backward search needs each state's predecessors. If the client
+// implements PredecessorsInterface they come from AllPredecessors; otherwise
+// moves must be reversible (if b is among a's neighbors then a must be among
+// b's) and AllNeighbors is used. Either way the backward search charges
+// NeighborDistance(b, a) for stepping back from a to b. EstimateDistance is
+// not used, and GoalReached is not consulted; the goal must be a concrete
+// state.
+type Bidirectional[T any] struct {
+	client      ClientInterface[T]
+	start, goal T
+	numExpanded int
+}
+
+// PredecessorsInterface lets Bidirectional search backward through moves that
+// aren't reversible.
+type PredecessorsInterface[T any] interface {
+	// AllPredecessors returns every state that has node among its
+	// AllNeighbors.
+	AllPredecessors(node T) []T
+}
+
+func NewBidirectional[T any](start, goal T, client ClientInterface[T]) *Bidirectional[T] {
+	return &Bidirectional[T]{client: client, start: start, goal: goal}
+}
+
+// NumExpanded returns the number of states whose neighbors were generated,
+// summed across both directions.
+func (a *Bidirectional[T]) NumExpanded() int {
+	return a.numExpanded
+}
+
+type biSide struct {
+	dist    map[string]uint
+	parent  map[string]string
+	settled map[string]bool
+	queue   collections.PriorityQueue[string]
+	last    uint // priority of the most recently settled state
+}
+
+func newBiSide(origin string) *biSide {
+	s := &biSide{
+		dist:    map[string]uint{origin: 0},
+		parent:  map[string]string{},
+		settled: map[string]bool{},
+		queue:   collections.NewPriorityQueue[string](collections.LessThan),
+	}
+	s.queue.Insert(origin, 0)
+	return s
+}
+
+// Solve returns the path from the goal back to the start, in the same order
+// as AStar.Solve, or nil if there is no path.
+func (a *Bidirectional[T]) Solve() []T {
+	startStr, goalStr := a.client.Serialize(a.start), a.client.Serialize(a.goal)
+	fwd, bwd := newBiSide(startStr), newBiSide(goalStr)
+
+	best := uint(math.MaxUint)
+	meet := ""
+	if startStr == goalStr {
+		best, meet = 0, startStr
+	}
+
+	progress := logger.NewProgress("bidirectional", "expanded")
+	progress.Gauge("forward", func() int64 { return int64(len(fwd.settled)) })
+	progress.Gauge("backward", func() int64 { return int64(len(bwd.settled)) })
+	defer progress.Done()
+
+	for forward := true; !fwd.queue.IsEmpty() && !bwd.queue.IsEmpty(); forward = !forward {
+		s, other := fwd, bwd
+		if !forward {
+			s, other = bwd, fwd
+		}
+
+		curStr, pri := s.queue.Next()
+		s.last = uint(pri)
+		s.settled[curStr] = true
+
+		// Every unsettled state on either side is at least last away from
+		// its origin, so no path through one can beat this.
+		if fwd.last+bwd.last >= best {
+			break
+		}
+
+		cur, err := a.client.Deserialize(curStr)
+		if err != nil {
+			panic("bad current")
+		}
+
+		a.numExpanded++
+		progress.Add(1)
+		next := a.client.AllNeighbors
+		if pc, ok := a.client.(PredecessorsInterface[T]); ok && !forward {
+			next = pc.AllPredecessors
+		}
+		for _, n := range next(cur) {
+			nStr := a.client.Serialize(n)
+			if s.settled[nStr] {
+				continue
+			}
+
+			var cost uint
+			if forward {
+				cost = a.client.NeighborDistance(cur, n)
+			} else {
+				cost = a.client.NeighborDistance(n, cur)
+			}
+
+			d := s.dist[curStr] + cost
+			if old, found := s.dist[nStr]; !found || d < old {
+				s.dist[nStr] = d
+				s.parent[nStr] = curStr
+				s.queue.Insert(nStr, int(d))
+			}
+
+			if od, found := other.dist[nStr]; found && s.dist[nStr]+od < best {
+				best, meet = s.dist[nStr]+od, nStr
+			}
+		}
+	}
+
+	if meet == "" {
+		return nil
+	}
+
+	// Walk backward from the meeting point to the start, then forward to
+	// the goal, and return it all goal-first.
+	keys := []string{}
+	for k, found := meet, true; found; k, found = fwd.parent[k] {
+		keys = append(keys, k)
+	}
+	keys = reverseSlice(keys)
+	for k, found := bwd.parent[meet]; found; k, found = bwd.parent[k] {
+		keys = append(keys, k)
+	}
+
+	path := make([]T, len(keys))
+	for i, k := range keys {
+		v, err := a.client.Deserialize(k)
+		if err != nil {
+			panic("bad path")
+		}
+		path[len(keys)-1-i] = v
+	}
+	return path
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astar
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/simmonmt/aoc/2025/common/logger"
+)
+
+// IDAStar is iterative-deepening A*. It repeatedly runs a depth-first search
+// bounded by f = g + h, raising the bound each time. Memory use is linear in
+// the length of the path rather than in the number of states seen, at the
+// cost of re-expanding states, so it suits huge state spaces with a good
+// estimate. Like AStar, it requires EstimateDistance to never overestimate.
+//
+// Plain IDA* raises the bound to the smallest f that exceeded it, which takes
+// an iteration per distinct f value; when step costs vary a lot (2021/23's
+// range over a factor of 1000) that's an iteration per handful of states.
+// Instead, the bound is raised far enough that each iteration should expand
+// about twice as many states as the last, and the iteration that first finds
+// the goal carries on looking for cheaper paths before it returns.
+type IDAStar[T any] struct {
+	client          ClientInterface[T]
+	start, goal     T
+	maxCacheEntries int
+	numExpanded     int
+}
+
+func NewIDAStar[T any](start, goal T, client ClientInterface[T]) *IDAStar[T] {
+	return &IDAStar[T]{client: client, start: start, goal: goal}
+}
+
+// SetMaxCacheEntries enables a transposition table: within each iteration,
+// the cheapest known cost to reach each of up to n states is remembered, and
+// any path reaching one of them no more cheaply is abandoned. This trades
+// (bounded) memory for not re-exploring states reached by different routes,
+// which in some state spaces is the difference between seconds and hours.
+func (a *IDAStar[T]) SetMaxCacheEntries(n int) {
+	a.maxCacheEntries = n
+}
+
+// NumExpanded returns the number of states whose neighbors were generated,
+// summed across every iteration.
+func (a *IDAStar[T]) NumExpanded() int {
+	return a.numExpanded
+}
+
+// idaGrowth is how many times as many states each iteration aims to expand
+// as the one before.
+const idaGrowth = 2
+
+// nextBound returns the bound for the next iteration given the f values that
+// exceeded this one (with how many times each did), aiming for roughly target
+// of them to fall within it. Returns false if there's nothing left to
+// explore.
+func nextBound(over map[uint]int, target int) (uint, bool) {
+	if len(over) == 0 {
+		return 0, false
+	}
+
+	fs := slices.Sorted(maps.Keys(over))
+	n := 0
+	for _, f := range fs {
+		n += over[f]
+		if n >= target {
+			return f, true
+		}
+	}
+	return fs[len(fs)-1], true
+}
+
+// Solve returns the path from the goal back to the start, in the same order
+// as AStar.Solve, or nil if there is no path.
+func (a *IDAStar[T]) Solve() []T {
+	if a.client.GoalReached(a.start, a.goal) {
+		return []T{a.start}
+	}
+
+	path := []T{a.start}
+	onPath := map[string]bool{a.client.Serialize(a.start): true}
+	var cache map[string]uint
+	var over map[uint]int // f values that exceeded bound, with counts
+	var best []T          // the cheapest path to the goal found so far
+	var expanded int      // states expanded this iteration
+	var free bool         // best costs nothing, so it can't be beaten
+
+	bound := a.client.EstimateDistance(a.start, a.goal)
+	progress := logger.NewProgress("idastar", "expanded")
+	progress.Gauge("bound", func() int64 { return int64(bound) })
+	progress.Gauge("depth", func() int64 { return int64(len(path)) })
+	defer progress.Done()
+
+	// search explores every extension of path with f <= bound. Once it
+	// finds the goal, it lowers bound below the cost of that path so that
+	// only cheaper ones are explored from then on, or stops if the path was
+	// free.
+	var search func(g uint)
+	search = func(g uint) {
+		if free {
+			return
+		}
+		cur := path[len(path)-1]
+		f := g + a.client.EstimateDistance(cur, a.goal)
+		if f > bound {
+			over[f]++
+			return
+		}
+		if a.client.GoalReached(cur, a.goal) {
+			best = slices.Clone(path)
+			if g == 0 {
+				free = true
+			} else {
+				bound = g - 1
+			}
+			return
+		}
+
+		a.numExpanded++
+		expanded++
+		progress.Add(1)
+		for _, n := range a.client.AllNeighbors(cur) {
+			key := a.client.Serialize(n)
+			if onPath[key] {
+				continue
+			}
+
+			ng := g + a.client.NeighborDistance(cur, n)
+			if a.maxCacheEntries > 0 {
+				if prev, found := cache[key]; found && ng >= prev {
+					continue
+				} else if found || len(cache) < a.maxCacheEntries {
+					cache[key] = ng
+				}
+			}
+
+			path = append(path, n)
+			onPath[key] = true
+			search(ng)
+			path = path[:len(path)-1]
+			delete(onPath, key)
+		}
+	}
+
+	for {
+		cache = map[string]uint{}
+		over = map[uint]int{}
+		expanded = 0
+		search(0)
+		if best != nil {
+			return reverseSlice(best)
+		}
+
+		next, ok := nextBound(over, idaGrowth*max(expanded, 1))
+		if !ok {
+			return nil // exhausted the state space
+		}
+		bound = next
+	}
+}
+
+func reverseSlice[T any](in []T) []T {
+	out := make([]T, len(in))
+	for i, v := range in {
+		out[len(in)-1-i] = v
+	}
+	return out
+}
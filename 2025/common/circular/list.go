@@ -0,0 +1,251 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package circular implements circular lists for ring games: a doubly-linked
+// List with O(1) insertion, removal and splicing, and an OrderedList that
+// trades those for O(log n) positional access.
+package circular
+
+import "fmt"
+
+type Node[T comparable] struct {
+	Value      T
+	prev, next *Node[T]
+}
+
+// Next returns the node clockwise from n.
+func (n *Node[T]) Next() *Node[T] {
+	return n.next
+}
+
+// Prev returns the node counterclockwise from n.
+func (n *Node[T]) Prev() *Node[T] {
+	return n.prev
+}
+
+type List[T comparable] struct {
+	head  *Node[T]
+	len   int
+	index map[T]*Node[T]
+}
+
+func New[T comparable]() *List[T] {
+	return &List[T]{}
+}
+
+// NewIndexed returns a list that maintains a map from values to nodes, which
+// allows Find. Values in an indexed list must be unique.
+func NewIndexed[T comparable]() *List[T] {
+	return &List[T]{index: map[T]*Node[T]{}}
+}
+
+// FromValues returns a list containing vals in order, along with the node for
+// each value.
+func FromValues[T comparable](vals []T, indexed bool) (*List[T], []*Node[T]) {
+	l := New[T]()
+	if indexed {
+		l = NewIndexed[T]()
+	}
+
+	nodes := make([]*Node[T], len(vals))
+	for i, v := range vals {
+		nodes[i] = l.Push(v)
+	}
+	return l, nodes
+}
+
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// Head returns the list's reference node (the first node added, unless it's
+// been removed), or nil if the list is empty.
+func (l *List[T]) Head() *Node[T] {
+	return l.head
+}
+
+func (l *List[T]) link(prev, n, next *Node[T]) {
+	n.prev, n.next = prev, next
+	prev.next, next.prev = n, n
+}
+
+func (l *List[T]) newNode(v T) *Node[T] {
+	n := &Node[T]{Value: v}
+	if l.index != nil {
+		if _, found := l.index[v]; found {
+			panic(fmt.Sprintf("duplicate value %v", v))
+		}
+		l.index[v] = n
+	}
+	l.len++
+	return n
+}
+
+// Push adds v counterclockwise from the head, which is to say at the end of
+// the list when read from the head.
+func (l *List[T]) Push(v T) *Node[T] {
+	if l.head == nil {
+		n := l.newNode(v)
+		n.prev, n.next = n, n
+		l.head = n
+		return n
+	}
+	return l.InsertBefore(l.head, v)
+}
+
+// InsertAfter adds v clockwise from at.
+func (l *List[T]) InsertAfter(at *Node[T], v T) *Node[T] {
+	n := l.newNode(v)
+	l.link(at, n, at.next)
+	return n
+}
+
+// InsertBefore adds v counterclockwise from at.
+func (l *List[T]) InsertBefore(at *Node[T], v T) *Node[T] {
+	n := l.newNode(v)
+	l.link(at.prev, n, at)
+	return n
+}
+
+func (l *List[T]) unlink(n *Node[T]) {
+	n.prev.next, n.next.prev = n.next, n.prev
+}
+
+// Remove removes n from the list, returning the node that was clockwise from
+// it (or nil if the list is now empty).
+func (l *List[T]) Remove(n *Node[T]) *Node[T] {
+	next := n.next
+	l.unlink(n)
+	n.prev, n.next = nil, nil
+	l.len--
+	if l.index != nil {
+		delete(l.index, n.Value)
+	}
+
+	if l.len == 0 {
+		l.head = nil
+		return nil
+	}
+	if l.head == n {
+		l.head = next
+	}
+	return next
+}
+
+// Find returns the node holding v. It panics if the list isn't indexed.
+func (l *List[T]) Find(v T) (*Node[T], bool) {
+	if l.index == nil {
+		panic("not indexed")
+	}
+	n, found := l.index[v]
+	return n, found
+}
+
+// Step returns the node k steps clockwise from n (counterclockwise if k is
+// negative). k is reduced modulo the list length, and the shorter direction
+// is walked.
+func (l *List[T]) Step(n *Node[T], k int) *Node[T] {
+	k %= l.len
+	if k < 0 {
+		k += l.len
+	}
+	if k > l.len/2 {
+		for k = l.len - k; k > 0; k-- {
+			n = n.prev
+		}
+		return n
+	}
+	for ; k > 0; k-- {
+		n = n.next
+	}
+	return n
+}
+
+// Shift moves n k places clockwise (counterclockwise if negative) relative to
+// the other nodes. Since n itself isn't counted, k is reduced modulo Len()-1.
+func (l *List[T]) Shift(n *Node[T], k int) {
+	if l.len <= 2 {
+		return
+	}
+	k %= l.len - 1
+	if k < 0 {
+		k += l.len - 1
+	}
+	if k == 0 {
+		return
+	}
+
+	prev := n.prev
+	l.unlink(n)
+	if l.head == n {
+		l.head = n.next
+	}
+
+	// Walk the remaining Len()-1 nodes.
+	l.len--
+	at := l.Step(prev, k)
+	l.len++
+	l.link(at, n, at.next)
+}
+
+// Splice moves the count nodes starting at first (going clockwise) so they
+// sit clockwise from after, in the same order. after must not be one of the
+// moved nodes.
+func (l *List[T]) Splice(first *Node[T], count int, after *Node[T]) {
+	if count <= 0 {
+		return
+	}
+
+	last := first
+	for i := 1; i < count; i++ {
+		if last == after {
+			panic("after is in the run")
+		}
+		last = last.next
+	}
+	if last == after {
+		panic("after is in the run")
+	}
+
+	for n := first; ; n = n.next {
+		if n == l.head {
+			l.head = last.next
+		}
+		if n == last {
+			break
+		}
+	}
+
+	// Unlink the run.
+	first.prev.next, last.next.prev = last.next, first.prev
+
+	// Relink it after after.
+	next := after.next
+	after.next, first.prev = first, after
+	last.next, next.prev = next, last
+}
+
+// Values returns the values in the list, reading clockwise from n (or the
+// head if n is nil).
+func (l *List[T]) Values(n *Node[T]) []T {
+	if n == nil {
+		n = l.head
+	}
+	out := make([]T, 0, l.len)
+	for i := 0; i < l.len; i++ {
+		out = append(out, n.Value)
+		n = n.next
+	}
+	return out
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circular
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestListBasics(t *testing.T) {
+	l := NewIndexed[int]()
+	if l.Head() != nil || l.Len() != 0 {
+		t.Fatalf("new list not empty")
+	}
+
+	a := l.Push(1)
+	l.Push(2)
+	l.InsertAfter(a, 5)
+	l.InsertBefore(a, 9)
+
+	if got, want := l.Values(nil), []int{1, 5, 2, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Values = %v, want %v", got, want)
+	}
+	if n, found := l.Find(2); !found || n.Next().Value != 9 || n.Prev().Value != 5 {
+		t.Errorf("Find(2) = %v, %v", n, found)
+	}
+
+	if got := l.Step(a, -1).Value; got != 9 {
+		t.Errorf("Step(a, -1) = %v, want 9", got)
+	}
+	if got := l.Step(a, 6).Value; got != 2 {
+		t.Errorf("Step(a, 6) = %v, want 2", got)
+	}
+
+	if next := l.Remove(a); next.Value != 5 {
+		t.Errorf("Remove(a) = %v, want 5", next.Value)
+	}
+	if _, found := l.Find(1); found {
+		t.Errorf("Find(1) after removal = _, true, want false")
+	}
+	if got, want := l.Values(nil), []int{5, 2, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Values after removal = %v, want %v", got, want)
+	}
+}
+
+func mixList(vals []int, rounds int) []int {
+	l, nodes := FromValues(vals, false)
+	var zero *Node[int]
+	for _, n := range nodes {
+		if n.Value == 0 {
+			zero = n
+		}
+	}
+	for i := 0; i < rounds; i++ {
+		for _, n := range nodes {
+			l.Shift(n, n.Value)
+		}
+	}
+	return l.Values(zero)
+}
+
+func mixOrdered(vals []int, rounds int) []int {
+	l, nodes := OrderedFromValues(vals)
+	var zero *OrderedNode[int]
+	for _, n := range nodes {
+		if n.Value == 0 {
+			zero = n
+		}
+	}
+	for i := 0; i < rounds; i++ {
+		for _, n := range nodes {
+			l.Shift(n, n.Value)
+		}
+	}
+
+	out := []int{}
+	for i, idx := 0, l.Index(zero); i < l.Len(); i++ {
+		out = append(out, l.At(idx+i).Value)
+	}
+	return out
+}
+
+func TestMixing(t *testing.T) {
+	// 2022/20 sample
+	vals := []int{1, 2, -3, 3, -2, 0, 4}
+	keyed := make([]int, len(vals))
+	for i, v := range vals {
+		keyed[i] = v * 811589153
+	}
+
+	type TestCase struct {
+		name string
+		mix  func([]int, int) []int
+	}
+
+	for _, tc := range []TestCase{{"list", mixList}, {"ordered", mixOrdered}} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := []int{0, 3, -2, 1, 2, -3, 4}
+			if got := tc.mix(vals, 1); !reflect.DeepEqual(got, want) {
+				t.Errorf("mix = %v, want %v", got, want)
+			}
+
+			got := tc.mix(keyed, 10)
+			sum := got[1000%len(got)] + got[2000%len(got)] + got[3000%len(got)]
+			if sum != 1623178306 {
+				t.Errorf("keyed sum = %v, want 1623178306", sum)
+			}
+		})
+	}
+}
+
+func TestCrabCups(t *testing.T) {
+	play := func(moves int) string {
+		cups := []int{3, 8, 9, 1, 2, 5, 4, 6, 7}
+		l, nodes := FromValues(cups, true)
+		cur := nodes[0]
+		for i := 0; i < moves; i++ {
+			picked := []int{cur.Next().Value, cur.Next().Next().Value, cur.Next().Next().Next().Value}
+			dest := cur.Value
+			for {
+				dest--
+				if dest == 0 {
+					dest = len(cups)
+				}
+				if dest != picked[0] && dest != picked[1] && dest != picked[2] {
+					break
+				}
+			}
+			destNode, _ := l.Find(dest)
+			l.Splice(cur.Next(), 3, destNode)
+			cur = cur.Next()
+		}
+
+		one, _ := l.Find(1)
+		out := ""
+		for _, v := range l.Values(one)[1:] {
+			out += strconv.Itoa(v)
+		}
+		return out
+	}
+
+	if got, want := play(10), "92658374"; got != want {
+		t.Errorf("10 moves = %v, want %v", got, want)
+	}
+	if got, want := play(100), "67384529"; got != want {
+		t.Errorf("100 moves = %v, want %v", got, want)
+	}
+}
+
+func TestMarbles(t *testing.T) {
+	// 2018/09 sample: 9 players, last marble 25
+	l := New[int]()
+	cur := l.Push(0)
+	scores := make([]int, 9)
+	for m := 1; m <= 25; m++ {
+		if m%23 == 0 {
+			victim := l.Step(cur, -7)
+			scores[m%len(scores)] += m + victim.Value
+			cur = l.Remove(victim)
+			continue
+		}
+		cur = l.InsertAfter(l.Step(cur, 1), m)
+	}
+
+	best := 0
+	for _, s := range scores {
+		best = max(best, s)
+	}
+	if best != 32 {
+		t.Errorf("high score = %v, want 32", best)
+	}
+}
+
+func TestSpinlock(t *testing.T) {
+	// 2017/17 sample
+	l := New[int]()
+	cur := l.Push(0)
+	for i := 1; i <= 2017; i++ {
+		cur = l.InsertAfter(l.Step(cur, 3), i)
+	}
+	if got := cur.Next().Value; got != 638 {
+		t.Errorf("after 2017 = %v, want 638", got)
+	}
+}
+
+func TestSpliceHead(t *testing.T) {
+	l, nodes := FromValues([]int{1, 2, 3, 4, 5}, false)
+	l.Splice(nodes[0], 2, nodes[3])
+	got := []string{}
+	for _, v := range l.Values(nil) {
+		got = append(got, strconv.Itoa(v))
+	}
+	if s := strings.Join(got, ","); s != "3,4,1,2,5" {
+		t.Errorf("Values = %v, want 3,4,1,2,5", s)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circular
+
+import "math/rand/v2"
+
+// OrderedNode is a node in an OrderedList. The list is kept as an implicit
+// treap (a randomly balanced binary tree ordered by position), with parent
+// pointers so a node can find its own position.
+type OrderedNode[T any] struct {
+	Value T
+
+	prio                uint32
+	size                int
+	left, right, parent *OrderedNode[T]
+}
+
+func (n *OrderedNode[T]) sz() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *OrderedNode[T]) update() {
+	n.size = 1 + n.left.sz() + n.right.sz()
+	if n.left != nil {
+		n.left.parent = n
+	}
+	if n.right != nil {
+		n.right.parent = n
+	}
+}
+
+// OrderedList is a circular list where finding a node's position, finding the
+// node at a position, and moving a node by an offset all take O(log n).
+type OrderedList[T any] struct {
+	root *OrderedNode[T]
+}
+
+func NewOrdered[T any]() *OrderedList[T] {
+	return &OrderedList[T]{}
+}
+
+// OrderedFromValues returns a list containing vals in order, along with the
+// node for each value.
+func OrderedFromValues[T any](vals []T) (*OrderedList[T], []*OrderedNode[T]) {
+	l := NewOrdered[T]()
+	nodes := make([]*OrderedNode[T], len(vals))
+	for i, v := range vals {
+		nodes[i] = l.InsertAt(i, v)
+	}
+	return l, nodes
+}
+
+// split divides t into the first k nodes and the rest.
+func split[T any](t *OrderedNode[T], k int) (*OrderedNode[T], *OrderedNode[T]) {
+	if t == nil {
+		return nil, nil
+	}
+	if t.left.sz() >= k {
+		a, b := split(t.left, k)
+		t.left = b
+		t.update()
+		if a != nil {
+			a.parent = nil
+		}
+		t.parent = nil
+		return a, t
+	}
+	a, b := split(t.right, k-t.left.sz()-1)
+	t.right = a
+	t.update()
+	if b != nil {
+		b.parent = nil
+	}
+	t.parent = nil
+	return t, b
+}
+
+func merge[T any](a, b *OrderedNode[T]) *OrderedNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.prio > b.prio {
+		a.right = merge(a.right, b)
+		a.update()
+		return a
+	}
+	b.left = merge(a, b.left)
+	b.update()
+	return b
+}
+
+func (l *OrderedList[T]) setRoot(n *OrderedNode[T]) {
+	l.root = n
+	if n != nil {
+		n.parent = nil
+	}
+}
+
+func (l *OrderedList[T]) Len() int {
+	return l.root.sz()
+}
+
+func (l *OrderedList[T]) reduce(i, n int) int {
+	i %= n
+	if i < 0 {
+		i += n
+	}
+	return i
+}
+
+// InsertAt inserts v so that it ends up at position i, where 0 <= i <= Len().
+func (l *OrderedList[T]) InsertAt(i int, v T) *OrderedNode[T] {
+	if i < 0 || i > l.Len() {
+		panic("bad index")
+	}
+	n := &OrderedNode[T]{Value: v, prio: rand.Uint32(), size: 1}
+	l.insertNode(i, n)
+	return n
+}
+
+func (l *OrderedList[T]) insertNode(i int, n *OrderedNode[T]) {
+	a, b := split(l.root, i)
+	l.setRoot(merge(merge(a, n), b))
+}
+
+// Index returns the position of n in the list.
+func (l *OrderedList[T]) Index(n *OrderedNode[T]) int {
+	idx := n.left.sz()
+	for ; n.parent != nil; n = n.parent {
+		if n.parent.right == n {
+			idx += n.parent.left.sz() + 1
+		}
+	}
+	return idx
+}
+
+// At returns the node at position i, which is reduced modulo Len().
+func (l *OrderedList[T]) At(i int) *OrderedNode[T] {
+	i = l.reduce(i, l.Len())
+	n := l.root
+	for {
+		switch ls := n.left.sz(); {
+		case i < ls:
+			n = n.left
+		case i == ls:
+			return n
+		default:
+			i -= ls + 1
+			n = n.right
+		}
+	}
+}
+
+// Next returns the node clockwise from n.
+func (l *OrderedList[T]) Next(n *OrderedNode[T]) *OrderedNode[T] {
+	return l.At(l.Index(n) + 1)
+}
+
+// Prev returns the node counterclockwise from n.
+func (l *OrderedList[T]) Prev(n *OrderedNode[T]) *OrderedNode[T] {
+	return l.At(l.Index(n) - 1)
+}
+
+func (l *OrderedList[T]) removeAt(i int) *OrderedNode[T] {
+	a, b := split(l.root, i)
+	n, c := split(b, 1)
+	l.setRoot(merge(a, c))
+	return n
+}
+
+// Remove removes n from the list.
+func (l *OrderedList[T]) Remove(n *OrderedNode[T]) {
+	l.removeAt(l.Index(n))
+	n.left, n.right, n.parent, n.size = nil, nil, nil, 1
+}
+
+// Shift moves n k places clockwise (counterclockwise if negative) relative to
+// the other nodes. Since n itself isn't counted, k is reduced modulo Len()-1.
+func (l *OrderedList[T]) Shift(n *OrderedNode[T], k int) {
+	num := l.Len()
+	if num <= 2 {
+		return
+	}
+
+	idx := l.Index(n)
+	l.removeAt(idx)
+	n.left, n.right, n.parent, n.size = nil, nil, nil, 1
+	l.insertNode(l.reduce(idx+k, num-1), n)
+}
+
+// Values returns the values in the list, starting from position 0.
+func (l *OrderedList[T]) Values() []T {
+	out := make([]T, 0, l.Len())
+	var walk func(n *OrderedNode[T])
+	walk = func(n *OrderedNode[T]) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		out = append(out, n.Value)
+		walk(n.right)
+	}
+	walk(l.root)
+	return out
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circular
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestOrderedAgainstSlice(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	l := NewOrdered[int]()
+	nodes := []*OrderedNode[int]{}
+	ref := []*OrderedNode[int]{}
+
+	for i := 0; i < 2000; i++ {
+		switch op := r.Intn(4); {
+		case op < 2 || len(ref) < 3:
+			idx := r.Intn(len(ref) + 1)
+			n := l.InsertAt(idx, i)
+			nodes = append(nodes, n)
+			ref = slices.Insert(ref, idx, n)
+
+		case op == 2:
+			idx := r.Intn(len(ref))
+			n := ref[idx]
+			l.Remove(n)
+			ref = slices.Delete(ref, idx, idx+1)
+			nodes = slices.DeleteFunc(nodes, func(o *OrderedNode[int]) bool { return o == n })
+
+		case op == 3:
+			n := nodes[r.Intn(len(nodes))]
+			k := r.Intn(1000) - 500
+			idx := slices.Index(ref, n)
+			ref = slices.Delete(ref, idx, idx+1)
+			newIdx := ((idx+k)%len(ref) + len(ref)) % len(ref)
+			ref = slices.Insert(ref, newIdx, n)
+			l.Shift(n, k)
+		}
+
+		if l.Len() != len(ref) {
+			t.Fatalf("%d: Len() = %v, want %v", i, l.Len(), len(ref))
+		}
+		for j := 0; j < 5 && len(ref) > 0; j++ {
+			idx := r.Intn(len(ref))
+			if got := l.Index(ref[idx]); got != idx {
+				t.Fatalf("%d: Index = %v, want %v", i, got, idx)
+			}
+			if got := l.At(idx); got != ref[idx] {
+				t.Fatalf("%d: At(%v) = %v, want %v",
+					i, idx, got.Value, ref[idx].Value)
+			}
+		}
+	}
+
+	want := []int{}
+	for _, n := range ref {
+		want = append(want, n.Value)
+	}
+	if got := l.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values mismatch")
+	}
+
+	if len(ref) > 1 {
+		if got := l.Next(ref[len(ref)-1]); got != ref[0] {
+			t.Errorf("Next(last) != first")
+		}
+		if got := l.Prev(ref[0]); got != ref[len(ref)-1] {
+			t.Errorf("Prev(first) != last")
+		}
+	}
+}
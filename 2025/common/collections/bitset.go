@@ -0,0 +1,183 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math/bits"
+	"strings"
+)
+
+// Bitset is an immutable set of non-negative integers, meant for search
+// states like "which keys have been collected". The first 64 bits live in a
+// uint64; anything beyond that spills into a string so that Bitsets stay
+// comparable and can be used directly as (part of) a map key. Equal sets
+// always compare equal with ==. The zero value is the empty set.
+type Bitset struct {
+	lo uint64
+	hi string // words 1..n as little-endian uint64s, no trailing zero words
+}
+
+// NewBitset returns a set containing each of elems.
+func NewBitset(elems ...int) Bitset {
+	b := Bitset{}
+	for _, e := range elems {
+		b = b.Set(e)
+	}
+	return b
+}
+
+// words returns the set as a slice of uint64s, lowest bits first. The slice
+// is newly allocated and has at least n entries.
+func (b Bitset) words(n int) []uint64 {
+	num := 1 + len(b.hi)/8
+	out := make([]uint64, max(n, num))
+	out[0] = b.lo
+	for i := 1; i < num; i++ {
+		out[i] = b.word(i)
+	}
+	return out
+}
+
+func (b Bitset) word(i int) uint64 {
+	if i == 0 {
+		return b.lo
+	}
+	off := (i - 1) * 8
+	if off >= len(b.hi) {
+		return 0
+	}
+	return binary.LittleEndian.Uint64([]byte(b.hi[off : off+8]))
+}
+
+func bitsetFromWords(words []uint64) Bitset {
+	for len(words) > 1 && words[len(words)-1] == 0 {
+		words = words[:len(words)-1]
+	}
+
+	b := Bitset{lo: words[0]}
+	if len(words) > 1 {
+		buf := make([]byte, 8*(len(words)-1))
+		for i, w := range words[1:] {
+			binary.LittleEndian.PutUint64(buf[i*8:], w)
+		}
+		b.hi = string(buf)
+	}
+	return b
+}
+
+func checkElem(i int) {
+	if i < 0 {
+		panic(fmt.Sprintf("negative bitset element %d", i))
+	}
+}
+
+func (b Bitset) Has(i int) bool {
+	checkElem(i)
+	return b.word(i/64)&(1<<(i%64)) != 0
+}
+
+// Set returns b with i added.
+func (b Bitset) Set(i int) Bitset {
+	checkElem(i)
+	if i < 64 {
+		return Bitset{lo: b.lo | 1<<i, hi: b.hi}
+	}
+	words := b.words(i/64 + 1)
+	words[i/64] |= 1 << (i % 64)
+	return bitsetFromWords(words)
+}
+
+// Clear returns b with i removed.
+func (b Bitset) Clear(i int) Bitset {
+	checkElem(i)
+	if i < 64 {
+		return Bitset{lo: b.lo &^ (1 << i), hi: b.hi}
+	}
+	if !b.Has(i) {
+		return b
+	}
+	words := b.words(0)
+	words[i/64] &^= 1 << (i % 64)
+	return bitsetFromWords(words)
+}
+
+// combine applies op word-by-word to b and o.
+func (b Bitset) combine(o Bitset, op func(x, y uint64) uint64) Bitset {
+	if b.hi == "" && o.hi == "" {
+		return Bitset{lo: op(b.lo, o.lo)}
+	}
+
+	n := 1 + max(len(b.hi), len(o.hi))/8
+	bw, ow := b.words(n), o.words(n)
+	for i := range bw {
+		bw[i] = op(bw[i], ow[i])
+	}
+	return bitsetFromWords(bw)
+}
+
+func (b Bitset) Union(o Bitset) Bitset {
+	return b.combine(o, func(x, y uint64) uint64 { return x | y })
+}
+
+func (b Bitset) Intersect(o Bitset) Bitset {
+	return b.combine(o, func(x, y uint64) uint64 { return x & y })
+}
+
+// Difference returns the elements of b that aren't in o.
+func (b Bitset) Difference(o Bitset) Bitset {
+	return b.combine(o, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// IsSubset returns true if every element of b is also in o.
+func (b Bitset) IsSubset(o Bitset) bool {
+	return b.Difference(o).IsEmpty()
+}
+
+func (b Bitset) IsEmpty() bool {
+	return b == Bitset{}
+}
+
+// Count returns the number of elements in the set.
+func (b Bitset) Count() int {
+	n := bits.OnesCount64(b.lo)
+	for i := 1; i <= len(b.hi)/8; i++ {
+		n += bits.OnesCount64(b.word(i))
+	}
+	return n
+}
+
+// All yields the elements of the set in ascending order.
+func (b Bitset) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; i <= len(b.hi)/8; i++ {
+			for w := b.word(i); w != 0; w &= w - 1 {
+				if !yield(i*64 + bits.TrailingZeros64(w)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (b Bitset) String() string {
+	elems := []string{}
+	for e := range b.All() {
+		elems = append(elems, fmt.Sprint(e))
+	}
+	return "{" + strings.Join(elems, " ") + "}"
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestBitset(t *testing.T) {
+	b := NewBitset(1, 5, 63, 64, 200)
+	if got := b.Count(); got != 5 {
+		t.Errorf("Count() = %v, want 5", got)
+	}
+	if got, want := slices.Collect(b.All()), []int{1, 5, 63, 64, 200}; !reflect.DeepEqual(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if got, want := b.String(), "{1 5 63 64 200}"; got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+
+	// Clearing the high bits must shrink back to the small representation
+	// so that equal sets stay ==.
+	if got, want := b.Clear(64).Clear(200), NewBitset(5, 1, 63); got != want {
+		t.Errorf("Clear = %v, want %v", got, want)
+	}
+	if got := b.Clear(1000); got != b {
+		t.Errorf("Clear(1000) = %v, want %v", got, b)
+	}
+
+	other := NewBitset(5, 64, 130)
+	if got, want := b.Union(other), NewBitset(1, 5, 63, 64, 130, 200); got != want {
+		t.Errorf("Union = %v, want %v", got, want)
+	}
+	if got, want := b.Intersect(other), NewBitset(5, 64); got != want {
+		t.Errorf("Intersect = %v, want %v", got, want)
+	}
+	if got, want := b.Difference(other), NewBitset(1, 63, 200); got != want {
+		t.Errorf("Difference = %v, want %v", got, want)
+	}
+	if !NewBitset(5, 64).IsSubset(b) || other.IsSubset(b) {
+		t.Errorf("IsSubset mismatch")
+	}
+
+	if !(Bitset{}).IsEmpty() || b.IsEmpty() {
+		t.Errorf("IsEmpty mismatch")
+	}
+
+	seen := map[Bitset]bool{NewBitset(3, 100): true}
+	if !seen[NewBitset(100).Set(3)] {
+		t.Errorf("equal sets produced different map keys")
+	}
+}
+
+func TestBitsetRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	naive := map[int]bool{}
+	b := Bitset{}
+	for round := 0; round < 2000; round++ {
+		i := rng.Intn(300)
+		if rng.Intn(2) == 0 {
+			b = b.Set(i)
+			naive[i] = true
+		} else {
+			b = b.Clear(i)
+			delete(naive, i)
+		}
+
+		if b.Has(i) != naive[i] {
+			t.Fatalf("round %d: Has(%v) = %v, want %v", round, i, b.Has(i), naive[i])
+		}
+		if b.Count() != len(naive) {
+			t.Fatalf("round %d: Count() = %v, want %v", round, b.Count(), len(naive))
+		}
+	}
+
+	want := []int{}
+	for i := range naive {
+		want = append(want, i)
+	}
+	slices.Sort(want)
+	if got := slices.Collect(b.All()); !reflect.DeepEqual(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if rebuilt := NewBitset(want...); rebuilt != b {
+		t.Errorf("NewBitset(%v) = %v, want %v", want, rebuilt, b)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"iter"
+
+	"github.com/simmonmt/aoc/2025/common/mtsmath"
+)
+
+// Counter is a multiset: a map from values to their (arbitrarily large)
+// multiplicities. Values whose count drops to zero are removed. The zero
+// value is an empty counter ready to use.
+type Counter[T comparable] struct {
+	counts map[T]mtsmath.Int
+}
+
+func NewCounter[T comparable]() *Counter[T] {
+	return &Counter[T]{counts: map[T]mtsmath.Int{}}
+}
+
+// NewCounterFrom returns a counter with a count of one for each element of
+// vals (more if it occurs more than once).
+func NewCounterFrom[T comparable](vals []T) *Counter[T] {
+	c := NewCounter[T]()
+	for _, v := range vals {
+		c.Add(v, 1)
+	}
+	return c
+}
+
+func (c *Counter[T]) Add(v T, n int64) {
+	c.AddInt(v, mtsmath.NewInt(n))
+}
+
+func (c *Counter[T]) AddInt(v T, n mtsmath.Int) {
+	if c.counts == nil {
+		c.counts = map[T]mtsmath.Int{}
+	}
+	sum := c.counts[v].Add(n)
+	if sum.IsZero() {
+		delete(c.counts, v)
+	} else {
+		c.counts[v] = sum
+	}
+}
+
+// Get returns the count for v, which is zero if v isn't present.
+func (c *Counter[T]) Get(v T) mtsmath.Int {
+	return c.counts[v]
+}
+
+// Len returns the number of distinct values in the counter.
+func (c *Counter[T]) Len() int {
+	return len(c.counts)
+}
+
+// Total returns the sum of all counts.
+func (c *Counter[T]) Total() mtsmath.Int {
+	var total mtsmath.Int
+	for _, n := range c.counts {
+		total = total.Add(n)
+	}
+	return total
+}
+
+// All iterates over the values and their counts, in no particular order.
+func (c *Counter[T]) All() iter.Seq2[T, mtsmath.Int] {
+	return func(yield func(T, mtsmath.Int) bool) {
+		for v, n := range c.counts {
+			if !yield(v, n) {
+				return
+			}
+		}
+	}
+}
+
+func (c *Counter[T]) Clone() *Counter[T] {
+	o := NewCounter[T]()
+	for v, n := range c.counts {
+		o.counts[v] = n
+	}
+	return o
+}
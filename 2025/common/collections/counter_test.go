@@ -0,0 +1,74 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	c := NewCounterFrom([]string{"a", "b", "a"})
+
+	if got, want := c.Get("a").String(), "2"; got != want {
+		t.Errorf(`Get("a") = %v, want %v`, got, want)
+	}
+	if got, want := c.Get("z").String(), "0"; got != want {
+		t.Errorf(`Get("z") = %v, want %v`, got, want)
+	}
+	if got, want := c.Len(), 2; got != want {
+		t.Errorf("Len() = %v, want %v", got, want)
+	}
+
+	c.Add("b", -1)
+	if got, want := c.Len(), 1; got != want {
+		t.Errorf("Len() after removal = %v, want %v", got, want)
+	}
+
+	clone := c.Clone()
+	c.Add("a", math.MaxInt64)
+	if got, want := c.Total().String(), "9223372036854775809"; got != want {
+		t.Errorf("Total() = %v, want %v", got, want)
+	}
+	if got, want := clone.Total().String(), "2"; got != want {
+		t.Errorf("clone Total() = %v, want %v", got, want)
+	}
+
+	num := 0
+	for v, n := range c.All() {
+		if v != "a" || n.String() != "9223372036854775809" {
+			t.Errorf("All() yielded %v, %v", v, n)
+		}
+		num++
+	}
+	if num != 1 {
+		t.Errorf("All() yielded %v values, want 1", num)
+	}
+}
+
+func TestCounterZeroValue(t *testing.T) {
+	var c Counter[int]
+	if got := c.Len(); got != 0 {
+		t.Errorf("Len() = %v, want 0", got)
+	}
+
+	c.Add(3, 2)
+	if got, want := c.Get(3).String(), "2"; got != want {
+		t.Errorf("Get(3) = %v, want %v", got, want)
+	}
+	if got, want := c.Clone().Total().String(), "2"; got != want {
+		t.Errorf("Clone().Total() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import "github.com/simmonmt/aoc/2025/common/mtsmath"
+
+// Fenwick is a Fenwick (binary indexed) tree, supporting point updates and
+// prefix sums over n elements in O(log n).
+type Fenwick[V mtsmath.Number] struct {
+	tree []V // 1-based
+}
+
+func NewFenwick[V mtsmath.Number](n int) *Fenwick[V] {
+	return &Fenwick[V]{tree: make([]V, n+1)}
+}
+
+// NewFenwickFrom returns a tree initialized with vals, in O(n).
+func NewFenwickFrom[V mtsmath.Number](vals []V) *Fenwick[V] {
+	f := &Fenwick[V]{tree: make([]V, len(vals)+1)}
+	copy(f.tree[1:], vals)
+	for i := 1; i < len(f.tree); i++ {
+		if j := i + (i & -i); j < len(f.tree) {
+			f.tree[j] += f.tree[i]
+		}
+	}
+	return f
+}
+
+func (f *Fenwick[V]) Len() int {
+	return len(f.tree) - 1
+}
+
+// Add adds delta to element i.
+func (f *Fenwick[V]) Add(i int, delta V) {
+	for i++; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// PrefixSum returns the sum of elements [0, i).
+func (f *Fenwick[V]) PrefixSum(i int) V {
+	var sum V
+	for ; i > 0; i -= i & -i {
+		sum += f.tree[i]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of elements [lo, hi).
+func (f *Fenwick[V]) RangeSum(lo, hi int) V {
+	return f.PrefixSum(hi) - f.PrefixSum(lo)
+}
+
+// Get returns element i.
+func (f *Fenwick[V]) Get(i int) V {
+	return f.RangeSum(i, i+1)
+}
+
+// Set sets element i to v.
+func (f *Fenwick[V]) Set(i int, v V) {
+	f.Add(i, v-f.Get(i))
+}
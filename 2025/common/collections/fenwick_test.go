@@ -0,0 +1,68 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFenwick(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	const n = 50
+	naive := make([]int, n)
+	for i := range naive {
+		naive[i] = rng.Intn(100) - 50
+	}
+
+	f := NewFenwickFrom(naive)
+	if f.Len() != n {
+		t.Fatalf("Len() = %v, want %v", f.Len(), n)
+	}
+
+	for round := 0; round < 500; round++ {
+		i := rng.Intn(n)
+		switch rng.Intn(2) {
+		case 0:
+			delta := rng.Intn(100) - 50
+			f.Add(i, delta)
+			naive[i] += delta
+		case 1:
+			v := rng.Intn(100)
+			f.Set(i, v)
+			naive[i] = v
+		}
+
+		lo := rng.Intn(n + 1)
+		hi := lo + rng.Intn(n+1-lo)
+		want := 0
+		for j := lo; j < hi; j++ {
+			want += naive[j]
+		}
+		if got := f.RangeSum(lo, hi); got != want {
+			t.Fatalf("RangeSum(%v, %v) = %v, want %v", lo, hi, got, want)
+		}
+		if got := f.Get(i); got != naive[i] {
+			t.Fatalf("Get(%v) = %v, want %v", i, got, naive[i])
+		}
+	}
+
+	empty := NewFenwick[float64](3)
+	empty.Add(1, 1.5)
+	if got := empty.PrefixSum(3); got != 1.5 {
+		t.Errorf("PrefixSum = %v, want 1.5", got)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import "github.com/simmonmt/aoc/2025/common/mtsmath"
+
+// PrefixSums holds prefix sums of a slice, answering range sums in O(1).
+// Element i is the sum of the first i values.
+type PrefixSums[V mtsmath.Number] []V
+
+func NewPrefixSums[V mtsmath.Number](vals []V) PrefixSums[V] {
+	p := make(PrefixSums[V], len(vals)+1)
+	for i, v := range vals {
+		p[i+1] = p[i] + v
+	}
+	return p
+}
+
+// Sum returns the sum of values [lo, hi).
+func (p PrefixSums[V]) Sum(lo, hi int) V {
+	return p[hi] - p[lo]
+}
+
+// DiffArray accumulates range additions in O(1) each, producing the resulting
+// values in O(n) when asked.
+type DiffArray[V mtsmath.Number] struct {
+	diffs []V
+}
+
+func NewDiffArray[V mtsmath.Number](n int) *DiffArray[V] {
+	return &DiffArray[V]{diffs: make([]V, n+1)}
+}
+
+// AddRange adds delta to every element in [lo, hi).
+func (d *DiffArray[V]) AddRange(lo, hi int, delta V) {
+	d.diffs[lo] += delta
+	d.diffs[hi] -= delta
+}
+
+// Values returns the current value of every element.
+func (d *DiffArray[V]) Values() []V {
+	out := make([]V, len(d.diffs)-1)
+	var cur V
+	for i := range out {
+		cur += d.diffs[i]
+		out[i] = cur
+	}
+	return out
+}
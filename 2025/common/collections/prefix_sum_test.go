@@ -0,0 +1,44 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrefixSums(t *testing.T) {
+	p := NewPrefixSums([]int{3, 1, 4, 1, 5})
+	if got := p.Sum(0, 5); got != 14 {
+		t.Errorf("Sum(0,5) = %v, want 14", got)
+	}
+	if got := p.Sum(1, 3); got != 5 {
+		t.Errorf("Sum(1,3) = %v, want 5", got)
+	}
+	if got := p.Sum(2, 2); got != 0 {
+		t.Errorf("Sum(2,2) = %v, want 0", got)
+	}
+}
+
+func TestDiffArray(t *testing.T) {
+	d := NewDiffArray[int](6)
+	d.AddRange(0, 3, 2)
+	d.AddRange(2, 6, 5)
+	d.AddRange(5, 6, -1)
+
+	if got, want := d.Values(), []int{2, 2, 7, 5, 5, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"fmt"
+	"math"
+)
+
+// SegmentTreeOps defines the values stored in a SegmentTree and the updates
+// that can be applied to them.
+type SegmentTreeOps[S, F any] struct {
+	// Combine merges the summaries of two adjacent ranges. It must be
+	// associative, with Identity as its identity.
+	Combine  func(a, b S) S
+	Identity S
+
+	// Apply applies update f to the summary s of a range of size
+	// elements. Compose returns the update equivalent to applying g and
+	// then f. Both may be nil if ApplyRange isn't used.
+	Apply   func(f F, s S, size int) S
+	Compose func(f, g F) F
+}
+
+// SegmentTree is a segment tree with lazy propagation, supporting range
+// queries and range updates in O(log n).
+type SegmentTree[S, F any] struct {
+	ops     SegmentTreeOps[S, F]
+	n       int
+	tree    []S
+	lazy    []F
+	hasLazy []bool
+}
+
+func NewSegmentTree[S, F any](vals []S, ops SegmentTreeOps[S, F]) *SegmentTree[S, F] {
+	t := &SegmentTree[S, F]{
+		ops:     ops,
+		n:       len(vals),
+		tree:    make([]S, 4*max(len(vals), 1)),
+		lazy:    make([]F, 4*max(len(vals), 1)),
+		hasLazy: make([]bool, 4*max(len(vals), 1)),
+	}
+	if t.n > 0 {
+		t.build(1, 0, t.n, vals)
+	}
+	return t
+}
+
+func (t *SegmentTree[S, F]) build(node, nl, nr int, vals []S) {
+	if nr-nl == 1 {
+		t.tree[node] = vals[nl]
+		return
+	}
+	mid := (nl + nr) / 2
+	t.build(2*node, nl, mid, vals)
+	t.build(2*node+1, mid, nr, vals)
+	t.tree[node] = t.ops.Combine(t.tree[2*node], t.tree[2*node+1])
+}
+
+func (t *SegmentTree[S, F]) applyNode(node, size int, f F) {
+	t.tree[node] = t.ops.Apply(f, t.tree[node], size)
+	if t.hasLazy[node] {
+		t.lazy[node] = t.ops.Compose(f, t.lazy[node])
+	} else {
+		t.lazy[node] = f
+		t.hasLazy[node] = true
+	}
+}
+
+func (t *SegmentTree[S, F]) push(node, nl, nr int) {
+	if !t.hasLazy[node] {
+		return
+	}
+	mid := (nl + nr) / 2
+	t.applyNode(2*node, mid-nl, t.lazy[node])
+	t.applyNode(2*node+1, nr-mid, t.lazy[node])
+	t.hasLazy[node] = false
+}
+
+func (t *SegmentTree[S, F]) Len() int {
+	return t.n
+}
+
+// Set sets element i to s.
+func (t *SegmentTree[S, F]) Set(i int, s S) {
+	if i < 0 || i >= t.n {
+		panic(fmt.Sprintf("index %d out of range [0, %d)", i, t.n))
+	}
+	t.set(1, 0, t.n, i, s)
+}
+
+func (t *SegmentTree[S, F]) set(node, nl, nr, i int, s S) {
+	if nr-nl == 1 {
+		t.tree[node] = s
+		return
+	}
+	t.push(node, nl, nr)
+	mid := (nl + nr) / 2
+	if i < mid {
+		t.set(2*node, nl, mid, i, s)
+	} else {
+		t.set(2*node+1, mid, nr, i, s)
+	}
+	t.tree[node] = t.ops.Combine(t.tree[2*node], t.tree[2*node+1])
+}
+
+// Get returns element i.
+func (t *SegmentTree[S, F]) Get(i int) S {
+	return t.Query(i, i+1)
+}
+
+// Query returns the combined summary of elements [lo, hi).
+func (t *SegmentTree[S, F]) Query(lo, hi int) S {
+	if lo >= hi || t.n == 0 {
+		return t.ops.Identity
+	}
+	return t.query(1, 0, t.n, lo, hi)
+}
+
+func (t *SegmentTree[S, F]) query(node, nl, nr, lo, hi int) S {
+	if hi <= nl || nr <= lo {
+		return t.ops.Identity
+	}
+	if lo <= nl && nr <= hi {
+		return t.tree[node]
+	}
+	t.push(node, nl, nr)
+	mid := (nl + nr) / 2
+	return t.ops.Combine(
+		t.query(2*node, nl, mid, lo, hi),
+		t.query(2*node+1, mid, nr, lo, hi))
+}
+
+// ApplyRange applies update f to every element in [lo, hi).
+func (t *SegmentTree[S, F]) ApplyRange(lo, hi int, f F) {
+	if lo >= hi || t.n == 0 {
+		return
+	}
+	t.applyRange(1, 0, t.n, lo, hi, f)
+}
+
+func (t *SegmentTree[S, F]) applyRange(node, nl, nr, lo, hi int, f F) {
+	if hi <= nl || nr <= lo {
+		return
+	}
+	if lo <= nl && nr <= hi {
+		t.applyNode(node, nr-nl, f)
+		return
+	}
+	t.push(node, nl, nr)
+	mid := (nl + nr) / 2
+	t.applyRange(2*node, nl, mid, lo, hi, f)
+	t.applyRange(2*node+1, mid, nr, lo, hi, f)
+	t.tree[node] = t.ops.Combine(t.tree[2*node], t.tree[2*node+1])
+}
+
+// FindFirst returns the smallest i >= lo such that pred(Query(lo, i+1)) is
+// true, or -1 if there isn't one. pred must be monotone: once true for a
+// range, it must stay true as the range is extended to the right. For example,
+// with a max tree, pred s >= k finds the leftmost element >= k.
+func (t *SegmentTree[S, F]) FindFirst(lo int, pred func(S) bool) int {
+	if t.n == 0 {
+		return -1
+	}
+	acc := t.ops.Identity
+	return t.findFirst(1, 0, t.n, lo, pred, &acc)
+}
+
+func (t *SegmentTree[S, F]) findFirst(node, nl, nr, lo int, pred func(S) bool, acc *S) int {
+	if nr <= lo {
+		return -1
+	}
+	if nl >= lo {
+		combined := t.ops.Combine(*acc, t.tree[node])
+		if !pred(combined) {
+			*acc = combined
+			return -1
+		}
+		if nr-nl == 1 {
+			return nl
+		}
+	}
+
+	t.push(node, nl, nr)
+	mid := (nl + nr) / 2
+	if r := t.findFirst(2*node, nl, mid, lo, pred, acc); r >= 0 {
+		return r
+	}
+	return t.findFirst(2*node+1, mid, nr, lo, pred, acc)
+}
+
+func addInts(f, g int) int { return f + g }
+
+// NewRangeAddMinTree returns a tree over vals supporting range-add updates
+// and range-min queries. Empty ranges have a min of math.MaxInt.
+func NewRangeAddMinTree(vals []int) *SegmentTree[int, int] {
+	return NewSegmentTree(vals, SegmentTreeOps[int, int]{
+		Combine:  func(a, b int) int { return min(a, b) },
+		Identity: math.MaxInt,
+		Apply:    func(f, s, _ int) int { return s + f },
+		Compose:  addInts,
+	})
+}
+
+// NewRangeAddMaxTree returns a tree over vals supporting range-add updates
+// and range-max queries. Empty ranges have a max of math.MinInt.
+func NewRangeAddMaxTree(vals []int) *SegmentTree[int, int] {
+	return NewSegmentTree(vals, SegmentTreeOps[int, int]{
+		Combine:  func(a, b int) int { return max(a, b) },
+		Identity: math.MinInt,
+		Apply:    func(f, s, _ int) int { return s + f },
+		Compose:  addInts,
+	})
+}
+
+// NewRangeAddSumTree returns a tree over vals supporting range-add updates
+// and range-sum queries.
+func NewRangeAddSumTree(vals []int) *SegmentTree[int, int] {
+	return NewSegmentTree(vals, SegmentTreeOps[int, int]{
+		Combine:  addInts,
+		Identity: 0,
+		Apply:    func(f, s, size int) int { return s + f*size },
+		Compose:  addInts,
+	})
+}
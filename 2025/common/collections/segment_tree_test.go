@@ -0,0 +1,135 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collections
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSegmentTreeAgainstNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	type TestCase struct {
+		name    string
+		ctor    func([]int) *SegmentTree[int, int]
+		combine func(a, b int) int
+		ident   int
+	}
+
+	testCases := []TestCase{
+		TestCase{"min", NewRangeAddMinTree, func(a, b int) int { return min(a, b) }, math.MaxInt},
+		TestCase{"max", NewRangeAddMaxTree, func(a, b int) int { return max(a, b) }, math.MinInt},
+		TestCase{"sum", NewRangeAddSumTree, func(a, b int) int { return a + b }, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			const n = 37
+			naive := make([]int, n)
+			for i := range naive {
+				naive[i] = rng.Intn(100)
+			}
+			st := tc.ctor(naive)
+
+			for round := 0; round < 1000; round++ {
+				lo := rng.Intn(n + 1)
+				hi := lo + rng.Intn(n+1-lo)
+
+				switch rng.Intn(3) {
+				case 0:
+					delta := rng.Intn(21) - 10
+					st.ApplyRange(lo, hi, delta)
+					for i := lo; i < hi; i++ {
+						naive[i] += delta
+					}
+				case 1:
+					i, v := rng.Intn(n), rng.Intn(100)
+					st.Set(i, v)
+					naive[i] = v
+				}
+
+				want := tc.ident
+				for i := lo; i < hi; i++ {
+					want = tc.combine(want, naive[i])
+				}
+				if got := st.Query(lo, hi); got != want {
+					t.Fatalf("%d: Query(%v, %v) = %v, want %v",
+						round, lo, hi, got, want)
+				}
+				if i := rng.Intn(n); st.Get(i) != naive[i] {
+					t.Fatalf("%d: Get(%v) = %v, want %v",
+						round, i, st.Get(i), naive[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSegmentTreeFindFirst(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	const n = 41
+	naive := make([]int, n)
+	for i := range naive {
+		naive[i] = rng.Intn(100)
+	}
+	st := NewRangeAddMaxTree(naive)
+
+	for round := 0; round < 500; round++ {
+		if rng.Intn(2) == 0 {
+			lo := rng.Intn(n)
+			hi := lo + rng.Intn(n-lo) + 1
+			delta := rng.Intn(21) - 10
+			st.ApplyRange(lo, hi, delta)
+			for i := lo; i < hi; i++ {
+				naive[i] += delta
+			}
+		}
+
+		lo, k := rng.Intn(n), rng.Intn(120)
+		want := -1
+		for i := lo; i < n; i++ {
+			if naive[i] >= k {
+				want = i
+				break
+			}
+		}
+		if got := st.FindFirst(lo, func(s int) bool { return s >= k }); got != want {
+			t.Fatalf("FindFirst(%v, >= %v) = %v, want %v",
+				lo, k, got, want)
+		}
+	}
+
+	// With a sum tree, FindFirst finds where a prefix sum first reaches a
+	// target.
+	sums := NewRangeAddSumTree([]int{3, 1, 4, 1, 5})
+	if got := sums.FindFirst(1, func(s int) bool { return s >= 6 }); got != 3 {
+		t.Errorf("sum FindFirst = %v, want 3", got)
+	}
+
+	if got := NewRangeAddMinTree(nil).FindFirst(0, func(int) bool { return true }); got != -1 {
+		t.Errorf("empty FindFirst = %v, want -1", got)
+	}
+}
+
+func TestSegmentTreeEmpty(t *testing.T) {
+	st := NewRangeAddSumTree(nil)
+	st.ApplyRange(0, 1, 5)
+	if got := st.Query(0, 1); got != 0 {
+		t.Errorf("empty Query = %v, want 0", got)
+	}
+}
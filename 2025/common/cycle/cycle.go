@@ -0,0 +1,202 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cycle finds cycles in iterated simulations and uses them to skip
+// ahead to arbitrarily distant steps.
+//
+// A simulation is described by a step function and a key function. The key
+// identifies the part of the state that determines its future, so states can
+// carry extra information (a running total, a tower height) that's excluded
+// from the key but can still be extrapolated with MetricAt.
+package cycle
+
+type Mode int
+
+const (
+	// History remembers every state seen, using O(start+length) memory but
+	// stepping each state only once.
+	History Mode = iota
+
+	// Floyd uses Floyd's tortoise-and-hare algorithm, with O(1) memory.
+	Floyd
+
+	// Brent uses Brent's algorithm, with O(1) memory and usually fewer
+	// steps than Floyd.
+	Brent
+)
+
+func (m Mode) String() string {
+	switch m {
+	case History:
+		return "history"
+	case Floyd:
+		return "floyd"
+	case Brent:
+		return "brent"
+	default:
+		panic("bad mode")
+	}
+}
+
+// Cycle describes the repeating portion of a sequence of states: the state at
+// step Start is the first one to recur, and it recurs every Length steps.
+type Cycle struct {
+	Start, Length int64
+}
+
+// Reduce maps step n to the earliest step with the same state.
+func (c Cycle) Reduce(n int64) int64 {
+	if n < c.Start {
+		return n
+	}
+	return c.Start + (n-c.Start)%c.Length
+}
+
+type Detector[S any, K comparable] struct {
+	// Step returns the state following its argument. It must not modify
+	// its argument: History mode keeps every state it's given, and the
+	// other modes step some states more than once. States that hold
+	// slices or maps must be copied before being changed.
+	Step func(S) S
+	Key  func(S) K
+	Mode Mode
+}
+
+// Find runs the simulation from initial until a state repeats, and returns the
+// cycle. It never returns if the simulation doesn't cycle.
+func (d *Detector[S, K]) Find(initial S) Cycle {
+	switch d.Mode {
+	case History:
+		c, _ := d.findHistory(initial)
+		return c
+	case Floyd:
+		return d.findFloyd(initial)
+	case Brent:
+		return d.findBrent(initial)
+	default:
+		panic("bad mode")
+	}
+}
+
+// findHistory returns the cycle along with every state from step 0 through
+// step Start+Length.
+func (d *Detector[S, K]) findHistory(initial S) (Cycle, []S) {
+	seen := map[K]int64{}
+	states := []S{}
+
+	cur := initial
+	for i := int64(0); ; i++ {
+		k := d.Key(cur)
+		states = append(states, cur)
+		if first, found := seen[k]; found {
+			return Cycle{Start: first, Length: i - first}, states
+		}
+		seen[k] = i
+		cur = d.Step(cur)
+	}
+}
+
+func (d *Detector[S, K]) advance(s S, n int64) S {
+	for ; n > 0; n-- {
+		s = d.Step(s)
+	}
+	return s
+}
+
+// findStart locates the first repeated state given the cycle length, by
+// walking two pointers length steps apart until they meet.
+func (d *Detector[S, K]) findStart(initial S, length int64) Cycle {
+	tortoise, hare := initial, d.advance(initial, length)
+	start := int64(0)
+	for d.Key(tortoise) != d.Key(hare) {
+		tortoise, hare = d.Step(tortoise), d.Step(hare)
+		start++
+	}
+	return Cycle{Start: start, Length: length}
+}
+
+func (d *Detector[S, K]) findFloyd(initial S) Cycle {
+	tortoise, hare := d.Step(initial), d.Step(d.Step(initial))
+	for d.Key(tortoise) != d.Key(hare) {
+		tortoise = d.Step(tortoise)
+		hare = d.Step(d.Step(hare))
+	}
+
+	// tortoise is now inside the cycle; walk around it once to measure.
+	length := int64(1)
+	k := d.Key(tortoise)
+	for hare = d.Step(tortoise); d.Key(hare) != k; hare = d.Step(hare) {
+		length++
+	}
+
+	return d.findStart(initial, length)
+}
+
+func (d *Detector[S, K]) findBrent(initial S) Cycle {
+	power, length := int64(1), int64(1)
+	tortoise, hare := initial, d.Step(initial)
+	for d.Key(tortoise) != d.Key(hare) {
+		if power == length {
+			tortoise = hare
+			power *= 2
+			length = 0
+		}
+		hare = d.Step(hare)
+		length++
+	}
+
+	return d.findStart(initial, length)
+}
+
+// StateAt returns the state after n steps from initial.
+func (d *Detector[S, K]) StateAt(initial S, n int64) S {
+	if d.Mode == History {
+		c, states := d.findHistory(initial)
+		if n < int64(len(states)) {
+			return states[n]
+		}
+		return states[c.Reduce(n)]
+	}
+
+	c := d.Find(initial)
+	return d.advance(initial, c.Reduce(n))
+}
+
+// MetricAt returns metric of the state after n steps from initial, for metrics
+// that change by the same amount every time around the cycle (a running
+// total, a height). Metrics that depend only on the key can use StateAt
+// instead.
+func (d *Detector[S, K]) MetricAt(initial S, n int64, metric func(S) int64) int64 {
+	var c Cycle
+	var at func(i int64) int64
+
+	if d.Mode == History {
+		var states []S
+		c, states = d.findHistory(initial)
+		if n < int64(len(states)) {
+			return metric(states[n])
+		}
+		at = func(i int64) int64 { return metric(states[i]) }
+	} else {
+		c = d.Find(initial)
+		if n < c.Start+c.Length {
+			return metric(d.advance(initial, n))
+		}
+		at = func(i int64) int64 { return metric(d.advance(initial, i)) }
+	}
+
+	perCycle := at(c.Start+c.Length) - at(c.Start)
+	numCycles := (n - c.Start) / c.Length
+	return at(c.Reduce(n)) + numCycles*perCycle
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cycle
+
+import (
+	"fmt"
+	"testing"
+)
+
+type testState struct {
+	val   int
+	total int64
+}
+
+func testStep(s testState) testState {
+	next := (s.val*s.val + 7) % 101
+	return testState{val: next, total: s.total + int64(next)}
+}
+
+func testKey(s testState) int { return s.val }
+
+var modes = []Mode{History, Floyd, Brent}
+
+func TestFind(t *testing.T) {
+	for _, initial := range []int{0, 3, 50, 99} {
+		// Brute force the expected cycle.
+		seen := map[int]int64{}
+		s := testState{val: initial}
+		var want Cycle
+		for i := int64(0); ; i++ {
+			if first, found := seen[s.val]; found {
+				want = Cycle{Start: first, Length: i - first}
+				break
+			}
+			seen[s.val] = i
+			s = testStep(s)
+		}
+
+		for _, mode := range modes {
+			t.Run(fmt.Sprintf("%d/%v", initial, mode), func(t *testing.T) {
+				d := &Detector[testState, int]{Step: testStep, Key: testKey, Mode: mode}
+				if got := d.Find(testState{val: initial}); got != want {
+					t.Errorf("Find = %+v, want %+v", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestStateAndMetricAt(t *testing.T) {
+	const initial = 3
+	const limit = 500
+
+	states := []testState{{val: initial}}
+	for i := 1; i <= limit; i++ {
+		states = append(states, testStep(states[i-1]))
+	}
+
+	for _, mode := range modes {
+		t.Run(fmt.Sprint(mode), func(t *testing.T) {
+			d := &Detector[testState, int]{Step: testStep, Key: testKey, Mode: mode}
+			for n := int64(0); n <= limit; n++ {
+				if got, want := d.StateAt(testState{val: initial}, n).val, states[n].val; got != want {
+					t.Errorf("StateAt(%v) = %v, want %v", n, got, want)
+				}
+				metric := func(s testState) int64 { return s.total }
+				if got, want := d.MetricAt(testState{val: initial}, n, metric), states[n].total; got != want {
+					t.Errorf("MetricAt(%v) = %v, want %v", n, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFarAhead(t *testing.T) {
+	// Counting by ones mod 7, carrying a running total of steps.
+	step := func(s testState) testState {
+		return testState{val: (s.val + 1) % 7, total: s.total + 1}
+	}
+	key := func(s testState) int { return s.val }
+
+	for _, mode := range modes {
+		d := &Detector[testState, int]{Step: step, Key: key, Mode: mode}
+		const n = 1_000_000_000_000
+		if got, want := d.StateAt(testState{}, n).val, n%7; got != want {
+			t.Errorf("%v: StateAt(1e12) = %v, want %v", mode, got, want)
+		}
+		if got := d.MetricAt(testState{}, n, func(s testState) int64 { return s.total }); got != n {
+			t.Errorf("%v: MetricAt(1e12) = %v, want %v", mode, got, int64(n))
+		}
+	}
+}
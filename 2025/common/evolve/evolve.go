@@ -0,0 +1,128 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package evolve runs population-style simulations, where a multiset of states
+// evolves by having each state turn into weighted successors every step.
+// Identical states are merged each step, so the cost of a step depends on the
+// number of distinct states rather than the size of the population.
+package evolve
+
+import (
+	"github.com/simmonmt/aoc/2025/common/collections"
+	"github.com/simmonmt/aoc/2025/common/mtsmath"
+)
+
+// Successor says that each unit of a state becomes Weight units of State.
+type Successor[T any] struct {
+	State  T
+	Weight int64
+}
+
+type Engine[T comparable] struct {
+	transition func(T) []Successor[T]
+	cache      map[T][]Successor[T]
+}
+
+// New returns an engine for the given transition function. The transition
+// must be a pure function of the state, as its results are cached.
+func New[T comparable](transition func(T) []Successor[T]) *Engine[T] {
+	return &Engine[T]{
+		transition: transition,
+		cache:      map[T][]Successor[T]{},
+	}
+}
+
+func (e *Engine[T]) successors(s T) []Successor[T] {
+	if succs, found := e.cache[s]; found {
+		return succs
+	}
+	succs := e.transition(s)
+	e.cache[s] = succs
+	return succs
+}
+
+// Step returns the population one step after c. c is not modified.
+func (e *Engine[T]) Step(c *collections.Counter[T]) *collections.Counter[T] {
+	out := collections.NewCounter[T]()
+	for s, n := range c.All() {
+		for _, succ := range e.successors(s) {
+			out.AddInt(succ.State, n.Mul(mtsmath.NewInt(succ.Weight)))
+		}
+	}
+	return out
+}
+
+// Run returns the population n steps after c. c is not modified.
+func (e *Engine[T]) Run(c *collections.Counter[T], n int) *collections.Counter[T] {
+	for i := 0; i < n; i++ {
+		c = e.Step(c)
+	}
+	return c
+}
+
+// recurrenceMargin is the number of extra samples, beyond the theoretical
+// minimum, used to confirm a recurrence before trusting it.
+const recurrenceMargin = 8
+
+// TotalAt returns the total population n steps after c, for n too large to
+// simulate directly. It simulates until it has seen enough totals to identify
+// the linear recurrence they follow, and then uses the recurrence to jump to
+// step n.
+//
+// The recurrence order is bounded by the number of reachable states, so
+// sampling continues until no new states have appeared for long enough that
+// none ever will, and the sample count comfortably exceeds twice the number
+// seen. Returns false if that doesn't happen within maxSamples steps.
+func (e *Engine[T]) TotalAt(c *collections.Counter[T], n int64, maxSamples int) (mtsmath.Int, bool) {
+	seen := map[T]bool{}
+	totals := []mtsmath.Rat{}
+	stable := 0 // steps since a new state last appeared
+	for i := int64(0); ; i++ {
+		total := c.Total()
+		if i == n {
+			return total, true
+		}
+
+		stable++
+		for s := range c.All() {
+			if !seen[s] {
+				seen[s] = true
+				stable = 0
+			}
+		}
+		totals = append(totals, total.Rat())
+
+		// A state can stay hidden (its weights cancelling out) for at
+		// most as many steps as there are states already seen, so once
+		// the set has gone that long without growing it's complete.
+		if stable > len(seen) && len(totals) >= 2*len(seen)+recurrenceMargin {
+			break
+		}
+		if len(totals) >= maxSamples {
+			return mtsmath.Int{}, false
+		}
+		c = e.Step(c)
+	}
+
+	rec, ok := FindRecurrence(totals)
+	if !ok {
+		return mtsmath.Int{}, false
+	}
+
+	v := rec.At(n)
+	if !v.IsInt() {
+		return mtsmath.Int{}, false
+	}
+	return mtsmath.IntFromBig(v.Num()), true
+}
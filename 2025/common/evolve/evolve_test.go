@@ -0,0 +1,137 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evolve
+
+import (
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/collections"
+	"github.com/simmonmt/aoc/2025/common/mtsmath"
+)
+
+func lanternfish(timer int) []Successor[int] {
+	if timer == 0 {
+		return []Successor[int]{{6, 1}, {8, 1}}
+	}
+	return []Successor[int]{{timer - 1, 1}}
+}
+
+func TestRun(t *testing.T) {
+	e := New(lanternfish)
+	initial := collections.NewCounterFrom([]int{3, 4, 3, 1, 2})
+
+	type TestCase struct {
+		days int
+		want string
+	}
+
+	for _, tc := range []TestCase{{18, "26"}, {80, "5934"}, {256, "26984457539"}} {
+		if got := e.Run(initial, tc.days).Total().String(); got != tc.want {
+			t.Errorf("Run(%v) = %v, want %v", tc.days, got, tc.want)
+		}
+	}
+
+	if got, want := initial.Total().String(), "5"; got != want {
+		t.Errorf("Run modified its input; total = %v, want %v", got, want)
+	}
+}
+
+func TestPolymer(t *testing.T) {
+	// 2021/14 sample, with pairs as states.
+	rules := map[string]byte{
+		"CH": 'B', "HH": 'N', "CB": 'H', "NH": 'C', "HB": 'C', "HC": 'B',
+		"HN": 'C', "NN": 'C', "BH": 'H', "NC": 'B', "NB": 'B', "BN": 'B',
+		"BB": 'N', "BC": 'B', "CC": 'N', "CN": 'C',
+	}
+	e := New(func(pair string) []Successor[string] {
+		mid := rules[pair]
+		return []Successor[string]{
+			{string([]byte{pair[0], mid}), 1},
+			{string([]byte{mid, pair[1]}), 1},
+		}
+	})
+
+	template := "NNCB"
+	initial := collections.NewCounter[string]()
+	for i := 0; i+1 < len(template); i++ {
+		initial.Add(template[i:i+2], 1)
+	}
+
+	pairs := e.Run(initial, 10)
+
+	// Count each pair's first element, plus the unchanging last element.
+	elems := collections.NewCounter[byte]()
+	for pair, n := range pairs.All() {
+		elems.AddInt(pair[0], n)
+	}
+	elems.Add(template[len(template)-1], 1)
+
+	if got, want := elems.Get('B').String(), "1749"; got != want {
+		t.Errorf("B = %v, want %v", got, want)
+	}
+	if got, want := elems.Get('H').String(), "161"; got != want {
+		t.Errorf("H = %v, want %v", got, want)
+	}
+}
+
+func TestTotalAt(t *testing.T) {
+	e := New(lanternfish)
+	initial := collections.NewCounterFrom([]int{3, 4, 3, 1, 2})
+
+	for _, n := range []int64{0, 5, 256, 1000} {
+		want := e.Run(initial, int(n)).Total()
+		got, ok := e.TotalAt(initial, n, 100)
+		if !ok || !got.Equal(want) {
+			t.Errorf("TotalAt(%v) = %v, %v, want %v, true",
+				n, got, ok, want)
+		}
+	}
+
+	if _, ok := e.TotalAt(initial, 1000, 10); ok {
+		t.Errorf("TotalAt with too few samples = _, true, want false")
+	}
+}
+
+func TestFindRecurrence(t *testing.T) {
+	fib := []int64{0, 1}
+	for len(fib) < 20 {
+		fib = append(fib, fib[len(fib)-1]+fib[len(fib)-2])
+	}
+
+	rec, ok := FindRecurrence(mtsmath.RatVector(fib))
+	if !ok {
+		t.Fatalf("FindRecurrence(fib) failed")
+	}
+	if got := len(rec.Coeffs); got != 2 {
+		t.Errorf("order = %v, want 2", got)
+	}
+	if got, want := rec.At(90).String(), "2880067194370816120"; got != want {
+		t.Errorf("fib(90) = %v, want %v", got, want)
+	}
+
+	geo := mtsmath.RatVector([]int64{3, 6, 12, 24, 48})
+	if rec, ok := FindRecurrence(geo); !ok || rec.At(10).String() != "3072" {
+		t.Errorf("geometric At(10) = %v, %v, want 3072, true",
+			rec.At(10), ok)
+	}
+
+	if _, ok := FindRecurrence(mtsmath.RatVector([]int64{1, 5, 2})); ok {
+		t.Errorf("FindRecurrence(short) = _, true, want false")
+	}
+
+	if rec, ok := FindRecurrence(mtsmath.RatVector([]int64{0, 0, 0})); !ok || !rec.At(100).IsZero() {
+		t.Errorf("zeros At(100) = %v, %v, want 0, true", rec.At(100), ok)
+	}
+}
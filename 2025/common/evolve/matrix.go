@@ -0,0 +1,96 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evolve
+
+import "github.com/simmonmt/aoc/2025/common/mtsmath"
+
+// Transitions is a fixed set of states and the matrix that moves a population
+// of them forward one step. If v[i] is the count of States[i], then
+// Matrix.MulVector(v) is the count one step later.
+type Transitions[T comparable] struct {
+	States []T
+	Index  map[T]int
+	Matrix *mtsmath.IntMatrix
+}
+
+// NewTransitions builds the transition matrix for rules, where each unit of a
+// state becomes one unit of each of its successors (listing a successor twice
+// counts it twice). States that only appear as successors are included, and
+// die out if they have no rules of their own. The order of States is
+// unspecified.
+func NewTransitions[T comparable](rules map[T][]T) *Transitions[T] {
+	t := &Transitions[T]{Index: map[T]int{}}
+	index := func(s T) int {
+		if i, found := t.Index[s]; found {
+			return i
+		}
+		t.Index[s] = len(t.States)
+		t.States = append(t.States, s)
+		return len(t.States) - 1
+	}
+
+	for from, tos := range rules {
+		index(from)
+		for _, to := range tos {
+			index(to)
+		}
+	}
+
+	n := len(t.States)
+	t.Matrix = mtsmath.NewIntMatrix(n, n)
+	for from, tos := range rules {
+		for _, to := range tos {
+			t.Matrix.AddAt(t.Index[to], t.Index[from], 1)
+		}
+	}
+	return t
+}
+
+// Vector converts a population into a column vector suitable for Matrix. It
+// panics if counts includes an unknown state.
+func (t *Transitions[T]) Vector(counts map[T]int64) []mtsmath.Int {
+	out := make([]mtsmath.Int, len(t.States))
+	for s, n := range counts {
+		i, found := t.Index[s]
+		if !found {
+			panic("unknown state")
+		}
+		out[i] = out[i].Add(mtsmath.NewInt(n))
+	}
+	return out
+}
+
+// After returns the population n steps after counts, keyed by state. States
+// with a zero count are omitted.
+func (t *Transitions[T]) After(counts map[T]int64, n int64) map[T]mtsmath.Int {
+	v := t.Matrix.Pow(n).MulVector(t.Vector(counts))
+
+	out := map[T]mtsmath.Int{}
+	for i, c := range v {
+		if !c.IsZero() {
+			out[t.States[i]] = c
+		}
+	}
+	return out
+}
+
+// TotalAfter returns the size of the population n steps after counts.
+func (t *Transitions[T]) TotalAfter(counts map[T]int64, n int64) mtsmath.Int {
+	var total mtsmath.Int
+	for _, c := range t.After(counts, n) {
+		total = total.Add(c)
+	}
+	return total
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evolve
+
+import (
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/collections"
+)
+
+func TestTransitions(t *testing.T) {
+	// 2021/06 lanternfish, as a 9x9 matrix.
+	rules := map[int][]int{0: {6, 8}}
+	for timer := 1; timer <= 8; timer++ {
+		rules[timer] = []int{timer - 1}
+	}
+	tr := NewTransitions(rules)
+	initial := map[int]int64{3: 2, 4: 1, 1: 1, 2: 1}
+
+	type TestCase struct {
+		days int64
+		want string
+	}
+
+	for _, tc := range []TestCase{{0, "5"}, {18, "26"}, {80, "5934"}, {256, "26984457539"}} {
+		if got := tr.TotalAfter(initial, tc.days).String(); got != tc.want {
+			t.Errorf("TotalAfter(%v) = %v, want %v", tc.days, got, tc.want)
+		}
+	}
+
+	// Well beyond int64, checked against the step-by-step engine.
+	want := New(lanternfish).Run(collections.NewCounterFrom([]int{3, 4, 3, 1, 2}), 1000)
+	got := tr.After(initial, 1000)
+	for timer, n := range got {
+		if !n.Equal(want.Get(timer)) {
+			t.Errorf("After(1000)[%v] = %v, want %v", timer, n, want.Get(timer))
+		}
+	}
+	if len(got) != want.Len() {
+		t.Errorf("After(1000) has %v states, want %v", len(got), want.Len())
+	}
+}
+
+func TestTransitionsDeadEnd(t *testing.T) {
+	tr := NewTransitions(map[string][]string{"a": {"b", "b"}})
+	got := tr.After(map[string]int64{"a": 3}, 1)
+	if len(got) != 1 || got["b"].String() != "6" {
+		t.Errorf("After(1) = %v, want map[b:6]", got)
+	}
+	if got := tr.After(map[string]int64{"a": 3}, 2); len(got) != 0 {
+		t.Errorf("After(2) = %v, want empty", got)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evolve
+
+import "github.com/simmonmt/aoc/2025/common/mtsmath"
+
+// Recurrence is a linear recurrence of order L = len(Coeffs):
+//
+//	a[n] = Coeffs[0]·a[n-1] + Coeffs[1]·a[n-2] + ... + Coeffs[L-1]·a[n-L]
+//
+// with Initial holding a[0] through a[L-1].
+type Recurrence struct {
+	Coeffs  []mtsmath.Rat
+	Initial []mtsmath.Rat
+}
+
+// FindRecurrence uses the Berlekamp-Massey algorithm to find the shortest
+// linear recurrence that generates seq. Returns false if seq is too short to
+// pin the recurrence down (it must be more than twice the recurrence order).
+func FindRecurrence(seq []mtsmath.Rat) (Recurrence, bool) {
+	one := mtsmath.RatFromInt(1)
+
+	// c is the connection polynomial: seq[n] + c[1]·seq[n-1] + ... = 0.
+	c := []mtsmath.Rat{one}
+	b := []mtsmath.Rat{one}
+	l, m := 0, 1
+	lastDisc := one
+
+	for n := range seq {
+		d := seq[n]
+		for i := 1; i <= l; i++ {
+			d = d.Add(c[i].Mul(seq[n-i]))
+		}
+		if d.IsZero() {
+			m++
+			continue
+		}
+
+		// c -= (d/lastDisc)·x^m·b
+		scale := d.Div(lastDisc)
+		next := append([]mtsmath.Rat(nil), c...)
+		for len(next) < len(b)+m {
+			next = append(next, mtsmath.Rat{})
+		}
+		for i, v := range b {
+			next[i+m] = next[i+m].Sub(scale.Mul(v))
+		}
+
+		if 2*l <= n {
+			b, lastDisc = c, d
+			l = n + 1 - l
+			m = 1
+		} else {
+			m++
+		}
+		c = next
+	}
+
+	if 2*l >= len(seq) {
+		return Recurrence{}, false
+	}
+
+	coeffs := make([]mtsmath.Rat, l)
+	for i := range coeffs {
+		if i+1 < len(c) {
+			coeffs[i] = c[i+1].Neg()
+		}
+	}
+	return Recurrence{
+		Coeffs:  coeffs,
+		Initial: append([]mtsmath.Rat(nil), seq[:l]...),
+	}, true
+}
+
+// mulMod returns a·b mod the characteristic polynomial of r, where a and b
+// have degree less than the recurrence order.
+func (r Recurrence) mulMod(a, b []mtsmath.Rat) []mtsmath.Rat {
+	l := len(r.Coeffs)
+	prod := make([]mtsmath.Rat, 2*l-1)
+	for i, av := range a {
+		if av.IsZero() {
+			continue
+		}
+		for j, bv := range b {
+			prod[i+j] = prod[i+j].Add(av.Mul(bv))
+		}
+	}
+
+	// x^L = Coeffs[0]·x^(L-1) + ... + Coeffs[L-1], so fold high terms down.
+	for k := len(prod) - 1; k >= l; k-- {
+		if prod[k].IsZero() {
+			continue
+		}
+		for i, c := range r.Coeffs {
+			prod[k-1-i] = prod[k-1-i].Add(prod[k].Mul(c))
+		}
+	}
+	return prod[:l]
+}
+
+// At returns a[n], using Kitamasa's method (exponentiation of x modulo the
+// characteristic polynomial), which takes O(L² log n) operations.
+func (r Recurrence) At(n int64) mtsmath.Rat {
+	l := len(r.Coeffs)
+	if l == 0 {
+		return mtsmath.Rat{}
+	}
+	if n < int64(l) {
+		return r.Initial[n]
+	}
+
+	// result = x^n mod charpoly, computed by squaring.
+	result := make([]mtsmath.Rat, l)
+	result[0] = mtsmath.RatFromInt(1)
+	base := make([]mtsmath.Rat, l)
+	if l == 1 {
+		base[0] = r.Coeffs[0] // x mod (x - c) = c
+	} else {
+		base[1] = mtsmath.RatFromInt(1)
+	}
+
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = r.mulMod(result, base)
+		}
+		base = r.mulMod(base, base)
+	}
+
+	var v mtsmath.Rat
+	for i, c := range result {
+		v = v.Add(c.Mul(r.Initial[i]))
+	}
+	return v
+}
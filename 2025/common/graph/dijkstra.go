@@ -1,6 +1,9 @@
 package graph
 
-import "github.com/simmonmt/aoc/2025/common/collections"
+import (
+	"github.com/simmonmt/aoc/2025/common/collections"
+	"github.com/simmonmt/aoc/2025/common/logger"
+)
 
 func reverseSlice[T any](in []T) []T {
 	out := make([]T, len(in))
@@ -21,8 +24,13 @@ func ShortestPath(start, end NodeID, graph Graph) []NodeID {
 
 	froms := map[NodeID]NodeID{}
 
+	progress := logger.NewProgress("dijkstra", "expanded")
+	progress.Gauge("settled", func() int64 { return int64(len(visited)) })
+	defer progress.Done()
+
 	for !queue.IsEmpty() {
 		cur, _ := queue.Next()
+		progress.Add(1)
 		curDist := distances[cur]
 
 		for _, neighbor := range graph.Neighbors(cur) {
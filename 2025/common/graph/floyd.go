@@ -0,0 +1,117 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/simmonmt/aoc/2025/common/logger"
+)
+
+// Distances holds the shortest distance between every pair of nodes. It
+// implements Graph as the complete graph of shortest paths, with each node
+// neighboring every other node it can reach.
+type Distances struct {
+	nodes []NodeID
+	index map[NodeID]int
+	dist  [][]int
+}
+
+// FloydWarshall computes the shortest distance between every pair of nodes,
+// using the Floyd-Warshall algorithm. Time is cubic in the number of nodes.
+// Edges to nodes outside of nodes are ignored.
+func FloydWarshall(nodes []NodeID, graph Graph) *Distances {
+	d := &Distances{
+		nodes: nodes,
+		index: map[NodeID]int{},
+		dist:  make([][]int, len(nodes)),
+	}
+	for i, id := range nodes {
+		d.index[id] = i
+	}
+
+	for i, id := range nodes {
+		row := make([]int, len(nodes))
+		for j := range row {
+			row[j] = math.MaxInt
+		}
+		row[i] = 0
+
+		for _, n := range graph.Neighbors(id) {
+			if j, found := d.index[n]; found && j != i {
+				row[j] = min(row[j], graph.NeighborDistance(id, n))
+			}
+		}
+		d.dist[i] = row
+	}
+
+	progress := logger.NewProgress("floyd-warshall", "pairs")
+	progress.SetTotal(int64(len(nodes)) * int64(len(nodes)))
+	defer progress.Done()
+
+	for k := range nodes {
+		progress.Add(int64(len(nodes)))
+		for i := range nodes {
+			ik := d.dist[i][k]
+			if ik == math.MaxInt {
+				continue
+			}
+			for j := range nodes {
+				if kj := d.dist[k][j]; kj != math.MaxInt && ik+kj < d.dist[i][j] {
+					d.dist[i][j] = ik + kj
+				}
+			}
+		}
+	}
+
+	return d
+}
+
+// Dist returns the shortest distance from a to b, or false if b can't be
+// reached from a.
+func (d *Distances) Dist(a, b NodeID) (int, bool) {
+	i, found := d.index[a]
+	if !found {
+		panic("unknown node " + string(a))
+	}
+	j, found := d.index[b]
+	if !found {
+		panic("unknown node " + string(b))
+	}
+
+	if d.dist[i][j] == math.MaxInt {
+		return 0, false
+	}
+	return d.dist[i][j], true
+}
+
+func (d *Distances) Neighbors(id NodeID) []NodeID {
+	out := []NodeID{}
+	for _, other := range d.nodes {
+		if _, ok := d.Dist(id, other); ok && other != id {
+			out = append(out, other)
+		}
+	}
+	return out
+}
+
+func (d *Distances) NeighborDistance(from, to NodeID) int {
+	dist, ok := d.Dist(from, to)
+	if !ok {
+		panic(fmt.Sprintf("no path from %v to %v", from, to))
+	}
+	return dist
+}
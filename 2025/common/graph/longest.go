@@ -0,0 +1,263 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/simmonmt/aoc/2025/common/logger"
+	"github.com/simmonmt/aoc/2025/common/parallel"
+)
+
+type weightedEdge struct {
+	to, weight int
+}
+
+// longestPathSearch holds the graph (reduced to node indices) for a longest
+// path search. Visited sets are bitmasks, which limits graphs to 64 nodes.
+type longestPathSearch struct {
+	nodes      []NodeID
+	adj        [][]weightedEdge
+	maxIn      []int // the heaviest edge into each node
+	start, end int
+
+	// globalBest is the best length found by any worker, for pruning.
+	globalBest atomic.Int64
+
+	progress *logger.Progress
+}
+
+func newLongestPathSearch(graph Graph, start, end NodeID) (*longestPathSearch, bool) {
+	// Graphs don't know their own nodes, so find every node reachable
+	// from start.
+	index := map[NodeID]int{start: 0}
+	s := &longestPathSearch{nodes: []NodeID{start}}
+	for i := 0; i < len(s.nodes); i++ {
+		for _, n := range graph.Neighbors(s.nodes[i]) {
+			if _, found := index[n]; !found {
+				index[n] = len(s.nodes)
+				s.nodes = append(s.nodes, n)
+			}
+		}
+	}
+	if len(s.nodes) > 64 {
+		panic(fmt.Sprintf("too many nodes (%d)", len(s.nodes)))
+	}
+
+	endIdx, found := index[end]
+	if !found {
+		return nil, false
+	}
+	s.end = endIdx
+
+	dist := graph.NeighborDistance
+	if lg, ok := graph.(interface{ MaxNeighborDistance(from, to NodeID) int }); ok {
+		dist = lg.MaxNeighborDistance
+	}
+
+	s.adj = make([][]weightedEdge, len(s.nodes))
+	s.maxIn = make([]int, len(s.nodes))
+	for i, id := range s.nodes {
+		for _, n := range graph.Neighbors(id) {
+			j := index[n]
+			if j == i {
+				continue
+			}
+			w := dist(id, n)
+			s.adj[i] = append(s.adj[i], weightedEdge{j, w})
+			s.maxIn[j] = max(s.maxIn[j], w)
+		}
+	}
+	s.globalBest.Store(-1)
+	return s, true
+}
+
+// bound returns an upper bound on the length of the rest of a path from
+// cur to the end avoiding visited: the heaviest way into each node that can
+// still be reached. Returns false if the end can't be reached at all.
+func (s *longestPathSearch) bound(cur int, visited uint64) (int, bool) {
+	seen := visited | 1<<cur
+	stack := []int{cur}
+	sum := 0
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, e := range s.adj[n] {
+			if seen&(1<<e.to) != 0 {
+				continue
+			}
+			seen |= 1 << e.to
+			sum += s.maxIn[e.to]
+			if e.to != s.end {
+				stack = append(stack, e.to) // can't pass through the end
+			}
+		}
+	}
+	return sum, seen&(1<<s.end) != 0
+}
+
+// raiseGlobalBest raises globalBest to length if it's lower. Other workers
+// may be raising it at the same time, so a plain load and store could
+// overwrite a larger value.
+func (s *longestPathSearch) raiseGlobalBest(length int64) {
+	for {
+		cur := s.globalBest.Load()
+		if length <= cur || s.globalBest.CompareAndSwap(cur, length) {
+			return
+		}
+	}
+}
+
+type partialPath struct {
+	path    []int
+	visited uint64
+	length  int
+}
+
+// dfs returns the longest path to the end that extends p, or false if
+// there isn't one (or none that could beat the best known).
+func (s *longestPathSearch) dfs(p partialPath) (partialPath, bool) {
+	best, found := partialPath{length: -1}, false
+
+	var visit func(cur int)
+	visit = func(cur int) {
+		s.progress.Add(1)
+		if cur == s.end {
+			if p.length > best.length {
+				best = partialPath{path: append([]int(nil), p.path...), visited: p.visited, length: p.length}
+				found = true
+				s.raiseGlobalBest(int64(p.length))
+			}
+			return
+		}
+
+		rest, ok := s.bound(cur, p.visited)
+		if !ok {
+			return
+		}
+		if ub := p.length + rest; ub <= best.length || int64(ub) < s.globalBest.Load() {
+			return
+		}
+
+		for _, e := range s.adj[cur] {
+			if p.visited&(1<<e.to) != 0 {
+				continue
+			}
+			p.path = append(p.path, e.to)
+			p.visited |= 1 << e.to
+			p.length += e.weight
+
+			visit(e.to)
+
+			p.path = p.path[:len(p.path)-1]
+			p.visited &^= 1 << e.to
+			p.length -= e.weight
+		}
+	}
+
+	visit(p.path[len(p.path)-1])
+	return best, found
+}
+
+func (s *longestPathSearch) route(p partialPath) Route {
+	r := Route{Cost: p.length}
+	for _, i := range p.path {
+		r.Nodes = append(r.Nodes, s.nodes[i])
+	}
+	return r
+}
+
+// LongestPath returns the longest path from start to end that visits no
+// node more than once, or false if end can't be reached. Edge weights must
+// be non-negative. If the graph has a MaxNeighborDistance method (as
+// WeightedGraph does), it's used in place of NeighborDistance so that the
+// longer of two parallel edges is used.
+//
+// This is NP-hard, so it's an exhaustive depth-first search pruned by an
+// upper bound on what the rest of the path could add. It's practical on
+// graphs of a few dozen nodes, like mazes contracted with ContractMaze.
+func LongestPath(graph Graph, start, end NodeID) (Route, bool) {
+	return LongestPathParallel(graph, start, end, 0, nil)
+}
+
+// LongestPathParallel is LongestPath, but it splits the search into the
+// distinct paths of length depth leaving start, and searches from them in
+// parallel using pool (which may be nil). The length is always the same as
+// LongestPath's; when there are ties, which path is returned depends on depth
+// but not on the number of workers or how they're scheduled.
+func LongestPathParallel(graph Graph, start, end NodeID, depth int, pool *parallel.Pool) (Route, bool) {
+	s, ok := newLongestPathSearch(graph, start, end)
+	if !ok {
+		return Route{}, false
+	}
+
+	// Expand the first depth levels breadth-first. Paths that reach the
+	// end along the way are complete, and are candidates in their own
+	// right.
+	frontier := []partialPath{{path: []int{s.start}, visited: 1 << s.start}}
+	candidates := []partialPath{}
+	for level := 0; level < depth; level++ {
+		next := []partialPath{}
+		for _, p := range frontier {
+			cur := p.path[len(p.path)-1]
+			if cur == s.end {
+				candidates = append(candidates, p)
+				continue
+			}
+			for _, e := range s.adj[cur] {
+				if p.visited&(1<<e.to) != 0 {
+					continue
+				}
+				next = append(next, partialPath{
+					path:    append(p.path[:len(p.path):len(p.path)], e.to),
+					visited: p.visited | 1<<e.to,
+					length:  p.length + e.weight,
+				})
+			}
+		}
+		frontier = next
+	}
+
+	s.progress = logger.NewProgress("longest path", "visited")
+	s.progress.Gauge("best", s.globalBest.Load)
+	defer s.progress.Done()
+
+	type result struct {
+		p     partialPath
+		found bool
+	}
+	results := parallel.Map(pool, frontier, func(p partialPath) result {
+		best, found := s.dfs(p)
+		return result{best, found}
+	})
+
+	best, found := partialPath{}, false
+	for _, c := range candidates {
+		if !found || c.length > best.length {
+			best, found = c, true
+		}
+	}
+	for _, r := range results {
+		if r.found && (!found || r.p.length > best.length) {
+			best, found = r.p, true
+		}
+	}
+
+	if !found {
+		return Route{}, false
+	}
+	return s.route(best), true
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/parallel"
+	"github.com/simmonmt/aoc/2025/common/pos"
+)
+
+// bruteLongest returns the length of the longest simple path from start to
+// end, or -1 if there isn't one.
+func bruteLongest(g *testGraph, start, end NodeID) int {
+	best := -1
+	visited := map[NodeID]bool{start: true}
+	var dfs func(cur NodeID, length int)
+	dfs = func(cur NodeID, length int) {
+		if cur == end {
+			best = max(best, length)
+			return
+		}
+		for n, w := range g.nodes[cur] {
+			if !visited[n] {
+				visited[n] = true
+				dfs(n, length+w)
+				visited[n] = false
+			}
+		}
+	}
+	dfs(start, 0)
+	return best
+}
+
+func checkPath(t *testing.T, g Graph, r Route, start, end NodeID) {
+	t.Helper()
+	if r.Nodes[0] != start || r.Nodes[len(r.Nodes)-1] != end {
+		t.Errorf("path %v doesn't run from %v to %v", r.Nodes, start, end)
+	}
+	seen := map[NodeID]bool{}
+	length := 0
+	for i, n := range r.Nodes {
+		if seen[n] {
+			t.Errorf("path %v revisits %v", r.Nodes, n)
+		}
+		seen[n] = true
+		if i > 0 {
+			length += g.NeighborDistance(r.Nodes[i-1], n)
+		}
+	}
+	if length != r.Cost {
+		t.Errorf("path %v has length %v, but Cost is %v", r.Nodes, length, r.Cost)
+	}
+}
+
+func TestLongestPathRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	pool := parallel.New()
+	pool.SetWorkers(4)
+	pool.SetChunkSize(1)
+
+	for round := 0; round < 30; round++ {
+		const numNodes = 11
+		dists := map[[2]NodeID]int{}
+		for i := 0; i < numNodes; i++ {
+			for j := i + 1; j < numNodes; j++ {
+				if rng.Intn(3) == 0 {
+					dists[[2]NodeID{NodeID(fmt.Sprint(i)), NodeID(fmt.Sprint(j))}] = 1 + rng.Intn(20)
+				}
+			}
+		}
+		g := symmetricGraph(dists)
+		if g.nodes["0"] == nil || g.nodes["10"] == nil {
+			continue
+		}
+
+		want := bruteLongest(g, "0", "10")
+		r, ok := LongestPath(g, "0", "10")
+		if ok != (want >= 0) || (ok && r.Cost != want) {
+			t.Fatalf("round %d: LongestPath() = %v, %v, want %v", round, r, ok, want)
+		}
+		if !ok {
+			continue
+		}
+		checkPath(t, g, r, "0", "10")
+
+		for depth := 1; depth <= 3; depth++ {
+			pr, ok := LongestPathParallel(g, "0", "10", depth, pool)
+			if !ok || pr.Cost != want {
+				t.Fatalf("round %d: LongestPathParallel(depth %d) = %v, %v, want %v",
+					round, depth, pr, ok, want)
+			}
+			checkPath(t, g, pr, "0", "10")
+		}
+	}
+}
+
+func TestLongestPathMaze(t *testing.T) {
+	// A 2023/23-style hike: the longest walk from top to bottom without
+	// stepping on any tile twice.
+	g := mustGrid(t, []string{
+		"#S#######",
+		"#.......#",
+		"#.#.###.#",
+		"#...#...#",
+		"#.#.#.#.#",
+		"#.......#",
+		"#######E#",
+	})
+
+	mg := ContractMaze(g, MazeSpec[rune]{
+		Passable: notWall,
+		Label: func(p pos.P2, r rune) (NodeID, bool) {
+			return NodeID(string(r)), r == 'S' || r == 'E'
+		},
+		Junctions: true,
+	})
+
+	r, ok := LongestPath(mg, "S", "E")
+	if !ok {
+		t.Fatalf("LongestPath() = _, false, want true")
+	}
+	checkPath(t, mg, r, "S", "E")
+
+	// Check against a brute-force walk over the grid itself.
+	start, end := pos.P2{X: 1, Y: 0}, pos.P2{X: 7, Y: 6}
+	best := -1
+	visited := map[pos.P2]bool{start: true}
+	var walk func(p pos.P2, length int)
+	walk = func(p pos.P2, length int) {
+		if p == end {
+			best = max(best, length)
+			return
+		}
+		for _, n := range g.AllNeighbors(p, false) {
+			if v, _ := g.Get(n); v != '#' && !visited[n] {
+				visited[n] = true
+				walk(n, length+1)
+				visited[n] = false
+			}
+		}
+	}
+	walk(start, 0)
+
+	if r.Cost != best {
+		t.Errorf("LongestPath() = %v, want %v", r.Cost, best)
+	}
+
+	if _, ok := LongestPath(mg, "S", "nowhere"); ok {
+		t.Errorf("LongestPath() to nowhere = _, true, want false")
+	}
+}
+
+func TestRaiseGlobalBest(t *testing.T) {
+	s := &longestPathSearch{}
+	s.globalBest.Store(-1)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1000; i >= 0; i-- {
+				s.raiseGlobalBest(int64(i*8 + w))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, want := s.globalBest.Load(), int64(1000*8+7); got != want {
+		t.Errorf("globalBest = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/simmonmt/aoc/2025/common/grid"
+	"github.com/simmonmt/aoc/2025/common/pos"
+)
+
+// Edge is a path between two nodes of a contracted maze that doesn't pass
+// through any other node.
+type Edge struct {
+	From, To NodeID
+	Length   int
+
+	// Requires holds the requirements (doors, say) of the cells passed
+	// through along the way, in order.
+	Requires []string
+}
+
+// WeightedGraph is a maze contracted down to its interesting points. It
+// implements Graph; when two nodes are joined by more than one edge,
+// NeighborDistance returns the shortest.
+type WeightedGraph struct {
+	nodes     []NodeID
+	positions map[NodeID]pos.P2
+	edges     map[NodeID][]Edge
+}
+
+// Nodes returns every node in the graph, sorted.
+func (g *WeightedGraph) Nodes() []NodeID {
+	return g.nodes
+}
+
+func (g *WeightedGraph) Position(id NodeID) pos.P2 {
+	return g.positions[id]
+}
+
+// Edges returns the edges leaving id.
+func (g *WeightedGraph) Edges(id NodeID) []Edge {
+	return g.edges[id]
+}
+
+func (g *WeightedGraph) Neighbors(id NodeID) []NodeID {
+	out := []NodeID{}
+	for _, e := range g.edges[id] {
+		if !slices.Contains(out, e.To) {
+			out = append(out, e.To)
+		}
+	}
+	return out
+}
+
+func (g *WeightedGraph) NeighborDistance(from, to NodeID) int {
+	best := -1
+	for _, e := range g.edges[from] {
+		if e.To == to && (best < 0 || e.Length < best) {
+			best = e.Length
+		}
+	}
+	if best < 0 {
+		panic(fmt.Sprintf("no edge from %v to %v", from, to))
+	}
+	return best
+}
+
+// MaxNeighborDistance is like NeighborDistance, but returns the longest of
+// the edges from from to to.
+func (g *WeightedGraph) MaxNeighborDistance(from, to NodeID) int {
+	best := -1
+	for _, e := range g.edges[from] {
+		if e.To == to {
+			best = max(best, e.Length)
+		}
+	}
+	if best < 0 {
+		panic(fmt.Sprintf("no edge from %v to %v", from, to))
+	}
+	return best
+}
+
+// MazeSpec describes how to contract a maze.
+type MazeSpec[T any] struct {
+	// Passable returns true if a walker can step from from into to, which
+	// contains v. Required.
+	Passable func(from, to pos.P2, v T) bool
+
+	// Label returns the node ID for p if it's a point of interest.
+	// Required.
+	Label func(p pos.P2, v T) (NodeID, bool)
+
+	// Requirement returns the requirement (if any) for passing through p.
+	Requirement func(p pos.P2, v T) (string, bool)
+
+	// Junctions makes every cell with three or more open neighbors a node
+	// too, with its position (as a string) as its ID. Mazes are then
+	// contracted by walking each corridor, so parallel corridors between
+	// two nodes become separate edges; this is what longest-path searches
+	// need. Without Junctions, edges are found by BFS from each labelled
+	// point, and are the shortest paths between labelled points that don't
+	// pass through another.
+	Junctions bool
+}
+
+type mazeContractor[T any] struct {
+	g    *grid.Grid[T]
+	spec MazeSpec[T]
+	out  *WeightedGraph
+	ids  map[pos.P2]NodeID
+}
+
+func (c *mazeContractor[T]) canStep(from, to pos.P2) bool {
+	v, _ := c.g.Get(to)
+	return c.spec.Passable(from, to, v)
+}
+
+// enterable returns true if p can be stepped into from any neighbor. This
+// excludes walls, which Passable may say can be stepped out of.
+func (c *mazeContractor[T]) enterable(p pos.P2) bool {
+	for _, n := range c.g.AllNeighbors(p, false) {
+		if c.canStep(n, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// open returns the neighbors of p that are connected to it in either
+// direction.
+func (c *mazeContractor[T]) open(p pos.P2) []pos.P2 {
+	out := []pos.P2{}
+	for _, n := range c.g.AllNeighbors(p, false) {
+		if c.canStep(p, n) || (c.canStep(n, p) && c.enterable(n)) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func (c *mazeContractor[T]) requirement(p pos.P2) (string, bool) {
+	if c.spec.Requirement == nil {
+		return "", false
+	}
+	v, _ := c.g.Get(p)
+	return c.spec.Requirement(p, v)
+}
+
+func (c *mazeContractor[T]) addEdge(e Edge) {
+	c.out.edges[e.From] = append(c.out.edges[e.From], e)
+}
+
+// walkCorridors follows each corridor leaving the node at start.
+func (c *mazeContractor[T]) walkCorridors(start pos.P2) {
+	from := c.ids[start]
+	for _, first := range c.open(start) {
+		if !c.canStep(start, first) {
+			continue
+		}
+
+		prev, cur := start, first
+		e := Edge{From: from, Length: 1}
+		for {
+			if id, found := c.ids[cur]; found {
+				if id != from {
+					e.To = id
+					c.addEdge(e)
+				}
+				break
+			}
+
+			if req, found := c.requirement(cur); found {
+				e.Requires = append(e.Requires, req)
+			}
+
+			// cur isn't a junction, so there's at most one way onward.
+			next, found := pos.P2{}, false
+			for _, n := range c.open(cur) {
+				if n != prev && c.canStep(cur, n) {
+					next, found = n, true
+				}
+			}
+			if !found {
+				break // dead end
+			}
+			prev, cur = cur, next
+			e.Length++
+		}
+	}
+}
+
+// bfs finds the shortest path from the node at start to each other node
+// reachable without passing through a third.
+func (c *mazeContractor[T]) bfs(start pos.P2) {
+	from := c.ids[start]
+	dist := map[pos.P2]int{start: 0}
+	parent := map[pos.P2]pos.P2{}
+
+	queue := []pos.P2{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if id, found := c.ids[cur]; found && cur != start {
+			e := Edge{From: from, To: id, Length: dist[cur]}
+			for p := parent[cur]; p != start; p = parent[p] {
+				if req, found := c.requirement(p); found {
+					e.Requires = append(e.Requires, req)
+				}
+			}
+			slices.Reverse(e.Requires)
+			c.addEdge(e)
+			continue
+		}
+
+		for _, n := range c.g.AllNeighbors(cur, false) {
+			if _, found := dist[n]; found || !c.canStep(cur, n) {
+				continue
+			}
+			dist[n] = dist[cur] + 1
+			parent[n] = cur
+			queue = append(queue, n)
+		}
+	}
+}
+
+// ContractMaze turns g into a weighted graph whose nodes are the points
+// labelled by spec (plus junctions, if requested).
+func ContractMaze[T any](g *grid.Grid[T], spec MazeSpec[T]) *WeightedGraph {
+	c := &mazeContractor[T]{
+		g:    g,
+		spec: spec,
+		out: &WeightedGraph{
+			positions: map[NodeID]pos.P2{},
+			edges:     map[NodeID][]Edge{},
+		},
+		ids: map[pos.P2]NodeID{},
+	}
+
+	g.Walk(func(p pos.P2, v T) {
+		id, found := spec.Label(p, v)
+		if !found && spec.Junctions && c.enterable(p) && len(c.open(p)) >= 3 {
+			id, found = NodeID(p.String()), true
+		}
+		if found {
+			if other, dup := c.out.positions[id]; dup {
+				panic(fmt.Sprintf("node %v at both %v and %v", id, other, p))
+			}
+			c.ids[p] = id
+			c.out.positions[id] = p
+			c.out.nodes = append(c.out.nodes, id)
+		}
+	})
+	slices.Sort(c.out.nodes)
+
+	for _, id := range c.out.nodes {
+		if spec.Junctions {
+			c.walkCorridors(c.out.positions[id])
+		} else {
+			c.bfs(c.out.positions[id])
+		}
+	}
+	return c.out
+}
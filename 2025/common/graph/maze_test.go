@@ -0,0 +1,182 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+
+	"github.com/simmonmt/aoc/2025/common/grid"
+	"github.com/simmonmt/aoc/2025/common/pos"
+)
+
+func mustGrid(t *testing.T, lines []string) *grid.Grid[rune] {
+	t.Helper()
+	g, err := grid.NewFromLines(lines, grid.RuneMapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func notWall(from, to pos.P2, r rune) bool {
+	return r != '#'
+}
+
+func TestContractMazeDucts(t *testing.T) {
+	// 2016/24 sample.
+	g := mustGrid(t, []string{
+		"###########",
+		"#0.1.....2#",
+		"#.#######.#",
+		"#4.......3#",
+		"###########",
+	})
+
+	mg := ContractMaze(g, MazeSpec[rune]{
+		Passable: notWall,
+		Label: func(p pos.P2, r rune) (NodeID, bool) {
+			return NodeID(string(r)), unicode.IsDigit(r)
+		},
+	})
+
+	if got, want := mg.Nodes(), []NodeID{"0", "1", "2", "3", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Nodes() = %v, want %v", got, want)
+	}
+	if got, want := mg.Neighbors("0"), []NodeID{"1", "4"}; !reflect.DeepEqual(got, want) &&
+		!reflect.DeepEqual(got, []NodeID{"4", "1"}) {
+		t.Errorf(`Neighbors("0") = %v, want %v`, got, want)
+	}
+	if got := mg.NeighborDistance("1", "2"); got != 6 {
+		t.Errorf(`NeighborDistance("1", "2") = %v, want 6`, got)
+	}
+
+	d := FloydWarshall(mg.Nodes(), mg)
+
+	type TestCase struct {
+		from, to NodeID
+		want     int
+	}
+	testCases := []TestCase{
+		TestCase{"0", "0", 0},
+		TestCase{"0", "4", 2},
+		TestCase{"4", "1", 4},
+		TestCase{"0", "3", 10},
+		TestCase{"4", "2", 10},
+	}
+	for _, tc := range testCases {
+		if got, ok := d.Dist(tc.from, tc.to); !ok || got != tc.want {
+			t.Errorf("Dist(%v, %v) = %v, %v, want %v, true", tc.from, tc.to, got, ok, tc.want)
+		}
+	}
+}
+
+func TestContractMazeDoors(t *testing.T) {
+	// 2019/18 sample.
+	g := mustGrid(t, []string{
+		"#########",
+		"#b.A.@.a#",
+		"#########",
+	})
+
+	mg := ContractMaze(g, MazeSpec[rune]{
+		Passable: notWall,
+		Label: func(p pos.P2, r rune) (NodeID, bool) {
+			return NodeID(string(r)), r == '@' || unicode.IsLower(r)
+		},
+		Requirement: func(p pos.P2, r rune) (string, bool) {
+			return string(unicode.ToLower(r)), unicode.IsUpper(r)
+		},
+	})
+
+	want := map[NodeID][]Edge{
+		"@": []Edge{
+			{From: "@", To: "a", Length: 2},
+			{From: "@", To: "b", Length: 4, Requires: []string{"a"}},
+		},
+		"a": []Edge{{From: "a", To: "@", Length: 2}},
+		"b": []Edge{{From: "b", To: "@", Length: 4, Requires: []string{"a"}}},
+	}
+	for id, edges := range want {
+		if got := mg.Edges(id); !reflect.DeepEqual(got, edges) {
+			t.Errorf("Edges(%v) = %+v, want %+v", id, got, edges)
+		}
+	}
+}
+
+func TestContractMazeJunctions(t *testing.T) {
+	// Two parallel corridors, one of them one-way.
+	g := mustGrid(t, []string{
+		"#S###",
+		"#...#",
+		"#v#.#",
+		"#...#",
+		"###E#",
+	})
+
+	spec := MazeSpec[rune]{
+		Passable: func(from, to pos.P2, r rune) bool {
+			switch r {
+			case '#':
+				return false
+			case 'v':
+				return to.Y > from.Y
+			default:
+				return true
+			}
+		},
+		Label: func(p pos.P2, r rune) (NodeID, bool) {
+			return NodeID(string(r)), r == 'S' || r == 'E'
+		},
+		Junctions: true,
+	}
+	mg := ContractMaze(g, spec)
+
+	if got, want := mg.Nodes(), []NodeID{"1,1", "3,3", "E", "S"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Nodes() = %v, want %v", got, want)
+	}
+
+	lengths := func(from, to NodeID) []int {
+		out := []int{}
+		for _, e := range mg.Edges(from) {
+			if e.To == to {
+				out = append(out, e.Length)
+			}
+		}
+		return out
+	}
+	if got := lengths("1,1", "3,3"); len(got) != 2 || got[0] != 4 || got[1] != 4 {
+		t.Errorf("edges 1,1 -> 3,3 = %v, want two of length 4", got)
+	}
+	if got := lengths("3,3", "1,1"); len(got) != 1 {
+		t.Errorf("edges 3,3 -> 1,1 = %v, want one (the slope is one-way)", got)
+	}
+
+	d := FloydWarshall(mg.Nodes(), mg)
+	if got, ok := d.Dist("S", "E"); !ok || got != 6 {
+		t.Errorf(`Dist("S", "E") = %v, %v, want 6, true`, got, ok)
+	}
+	if got, ok := d.Dist("E", "S"); !ok || got != 6 {
+		t.Errorf(`Dist("E", "S") = %v, %v, want 6, true`, got, ok)
+	}
+
+	// Without junctions, there's just the one shortest path.
+	spec.Junctions = false
+	mg = ContractMaze(g, spec)
+	if got := mg.NeighborDistance("S", "E"); got != 6 {
+		t.Errorf(`NeighborDistance("S", "E") = %v, want 6`, got)
+	}
+}
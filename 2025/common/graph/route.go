@@ -0,0 +1,261 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+
+	"github.com/simmonmt/aoc/2025/common/collections"
+	"github.com/simmonmt/aoc/2025/common/logger"
+)
+
+// Route is an ordered visit of nodes, and its total cost. For cycles, the
+// return to the first node is included in the cost but not repeated in
+// Nodes.
+type Route struct {
+	Nodes []NodeID
+	Cost  int
+}
+
+// weights returns the edge weights among nodes as a matrix, with -1 where
+// there's no edge. If longest is set and the graph has a MaxNeighborDistance
+// method (as WeightedGraph does), the longest of parallel edges is used.
+func weights(nodes []NodeID, graph Graph, longest bool) [][]int {
+	dist := graph.NeighborDistance
+	if lg, ok := graph.(interface{ MaxNeighborDistance(from, to NodeID) int }); ok && longest {
+		dist = lg.MaxNeighborDistance
+	}
+
+	index := map[NodeID]int{}
+	for i, id := range nodes {
+		if _, found := index[id]; found {
+			panic(fmt.Sprintf("duplicate node %v", id))
+		}
+		index[id] = i
+	}
+
+	w := make([][]int, len(nodes))
+	for i, id := range nodes {
+		w[i] = make([]int, len(nodes))
+		for j := range w[i] {
+			w[i][j] = -1
+		}
+		for _, n := range graph.Neighbors(id) {
+			if j, found := index[n]; found && j != i {
+				w[i][j] = dist(id, n)
+			}
+		}
+	}
+	return w
+}
+
+// maxHeldKarpNodes keeps heldKarp's tables (2^n * n entries) to a few hundred
+// megabytes.
+const maxHeldKarpNodes = 20
+
+// heldKarp finds the best route visiting each of nodes exactly once using
+// the Held-Karp dynamic program. The route starts at nodes[start], or
+// anywhere if start is negative. Memory is O(2^n * n), so n is limited to
+// maxHeldKarpNodes.
+func heldKarp(nodes []NodeID, graph Graph, start int, cycle, longest bool) (Route, bool) {
+	n := len(nodes)
+	if n == 0 {
+		return Route{}, false
+	}
+	if n > maxHeldKarpNodes {
+		panic(fmt.Sprintf("too many nodes (%d > %d)", n, maxHeldKarpNodes))
+	}
+
+	w := weights(nodes, graph, longest)
+	better := func(a, b int) bool {
+		if longest {
+			return a > b
+		}
+		return a < b
+	}
+
+	// best[mask*n+last] is the cost of the best route visiting exactly the
+	// nodes in mask and ending at last, with found tracking which entries
+	// are valid.
+	full := 1<<n - 1
+	best := make([]int, (full+1)*n)
+	found := make([]bool, (full+1)*n)
+	prev := make([]int8, (full+1)*n)
+	for i := 0; i < n; i++ {
+		if start < 0 || start == i {
+			best[(1<<i)*n+i], found[(1<<i)*n+i], prev[(1<<i)*n+i] = 0, true, -1
+		}
+	}
+
+	progress := logger.NewProgress("held-karp", "sets")
+	progress.SetTotal(int64(full))
+	defer progress.Done()
+
+	for mask := 1; mask <= full; mask++ {
+		progress.Add(1)
+		for last := 0; last < n; last++ {
+			cur := mask*n + last
+			if !found[cur] {
+				continue
+			}
+			for next := 0; next < n; next++ {
+				if mask&(1<<next) != 0 || w[last][next] < 0 {
+					continue
+				}
+				nxt := (mask|1<<next)*n + next
+				if cost := best[cur] + w[last][next]; !found[nxt] || better(cost, best[nxt]) {
+					best[nxt], found[nxt], prev[nxt] = cost, true, int8(last)
+				}
+			}
+		}
+	}
+
+	bestLast, bestCost := -1, 0
+	for last := 0; last < n; last++ {
+		cur := full*n + last
+		if !found[cur] {
+			continue
+		}
+		cost := best[cur]
+		if cycle {
+			if n > 1 && w[last][start] < 0 {
+				continue
+			}
+			if n > 1 {
+				cost += w[last][start]
+			}
+		}
+		if bestLast < 0 || better(cost, bestCost) {
+			bestLast, bestCost = last, cost
+		}
+	}
+	if bestLast < 0 {
+		return Route{}, false
+	}
+
+	route := Route{Nodes: make([]NodeID, n), Cost: bestCost}
+	for mask, last, i := full, bestLast, n-1; last >= 0; i-- {
+		route.Nodes[i] = nodes[last]
+		p := int(prev[mask*n+last])
+		mask &^= 1 << last
+		last = p
+	}
+	return route, true
+}
+
+// HamiltonianPath returns the shortest (or longest) path through graph
+// visiting each of nodes exactly once, or false if there isn't one. The path
+// begins at start if it's nonempty, and otherwise wherever is best. Edges to
+// nodes not in nodes are ignored; to allow passing through other nodes, use
+// the FloydWarshall Distances as the graph. Panics if there are more than 20
+// nodes.
+func HamiltonianPath(nodes []NodeID, graph Graph, start NodeID, longest bool) (Route, bool) {
+	startIdx := -1
+	if start != "" {
+		for i, id := range nodes {
+			if id == start {
+				startIdx = i
+			}
+		}
+		if startIdx < 0 {
+			panic(fmt.Sprintf("start %v not in nodes", start))
+		}
+	}
+	return heldKarp(nodes, graph, startIdx, false, longest)
+}
+
+// HamiltonianCycle returns the shortest (or longest) cycle through graph
+// visiting each of nodes exactly once and returning to nodes[0], or false if
+// there isn't one. Panics if there are more than 20 nodes.
+func HamiltonianCycle(nodes []NodeID, graph Graph, longest bool) (Route, bool) {
+	return heldKarp(nodes, graph, 0, true, longest)
+}
+
+// Orienteering describes a trip that starts at Start and has Budget time to
+// spend traveling between nodes (at a cost given by the graph) and visiting
+// them, collecting a reward from each visited node.
+type Orienteering struct {
+	Start  NodeID
+	Budget int
+
+	// VisitTime is the time spent at each node visited.
+	VisitTime int
+
+	// Reward returns the value collected by visiting id, given the time
+	// that will remain once the visit is over.
+	Reward func(id NodeID, remaining int) int
+}
+
+// OrienteeringResult holds the best reward collected by a trip visiting
+// exactly each set of nodes (by index into the nodes passed to Solve), for
+// every set that can be visited within the budget. The best overall is in
+// Best. Having every set makes it possible to split the nodes between
+// several travelers.
+type OrienteeringResult struct {
+	Best  int
+	BySet map[collections.Bitset]int
+}
+
+// Solve finds the best trip visiting some of nodes. It's a depth-first
+// search memoized on (current node, visited set, time remaining): a state
+// reached again with no more value than before can't lead anywhere new, since
+// everything that follows depends only on the state. nodes shouldn't include
+// Start. Travel uses graph.NeighborDistance, so graph will generally be
+// FloydWarshall Distances.
+func (o *Orienteering) Solve(nodes []NodeID, graph Graph) OrienteeringResult {
+	all := append([]NodeID{o.Start}, nodes...)
+	w := weights(all, graph, false)
+
+	type memoKey struct {
+		cur, remaining int
+		visited        collections.Bitset
+	}
+	memo := map[memoKey]int{}
+
+	res := OrienteeringResult{BySet: map[collections.Bitset]int{{}: 0}}
+
+	progress := logger.NewProgress("orienteering", "visited")
+	progress.Gauge("best", func() int64 { return int64(res.Best) })
+	progress.Gauge("sets", func() int64 { return int64(len(res.BySet)) })
+	defer progress.Done()
+
+	var dfs func(cur, remaining, value int, visited collections.Bitset)
+	dfs = func(cur, remaining, value int, visited collections.Bitset) {
+		key := memoKey{cur, remaining, visited}
+		if v, found := memo[key]; found && v >= value {
+			return
+		}
+		memo[key] = value
+		progress.Add(1)
+		if v, found := res.BySet[visited]; !found || value > v {
+			res.BySet[visited] = value
+		}
+		res.Best = max(res.Best, value)
+
+		for next := range nodes {
+			if visited.Has(next) || w[cur][next+1] < 0 {
+				continue
+			}
+			left := remaining - w[cur][next+1] - o.VisitTime
+			if left < 0 {
+				continue
+			}
+			dfs(next+1, left, value+o.Reward(nodes[next], left), visited.Set(next))
+		}
+	}
+	dfs(0, o.Budget, 0, collections.Bitset{})
+
+	return res
+}
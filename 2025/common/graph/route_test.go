@@ -0,0 +1,184 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/pos"
+)
+
+func symmetricGraph(dists map[[2]NodeID]int) *testGraph {
+	g := &testGraph{nodes: map[NodeID]map[NodeID]int{}}
+	for pair, d := range dists {
+		for _, p := range [][2]NodeID{pair, {pair[1], pair[0]}} {
+			if g.nodes[p[0]] == nil {
+				g.nodes[p[0]] = map[NodeID]int{}
+			}
+			g.nodes[p[0]][p[1]] = d
+		}
+	}
+	return g
+}
+
+func TestHamiltonianPath(t *testing.T) {
+	// 2015/09 sample.
+	g := symmetricGraph(map[[2]NodeID]int{
+		{"London", "Dublin"}:  464,
+		{"London", "Belfast"}: 518,
+		{"Dublin", "Belfast"}: 141,
+	})
+	nodes := []NodeID{"Belfast", "Dublin", "London"}
+
+	r, ok := HamiltonianPath(nodes, g, "", false)
+	if !ok || r.Cost != 605 {
+		t.Errorf("shortest = %v, %v, want 605", r, ok)
+	}
+	if want := []NodeID{"London", "Dublin", "Belfast"}; !reflect.DeepEqual(r.Nodes, want) &&
+		!reflect.DeepEqual(r.Nodes, []NodeID{"Belfast", "Dublin", "London"}) {
+		t.Errorf("shortest route = %v, want %v or its reverse", r.Nodes, want)
+	}
+
+	if r, ok := HamiltonianPath(nodes, g, "", true); !ok || r.Cost != 982 {
+		t.Errorf("longest = %v, %v, want 982", r, ok)
+	}
+
+	r, ok = HamiltonianPath(nodes, g, "Dublin", false)
+	if !ok || r.Cost != 141+518 || r.Nodes[0] != "Dublin" {
+		t.Errorf("shortest from Dublin = %v, %v, want cost %v", r, ok, 141+518)
+	}
+}
+
+func TestHamiltonianPathParallelEdges(t *testing.T) {
+	// A and B are joined by a short corridor and a long one.
+	g := mustGrid(t, []string{
+		"#######",
+		"#A...B#",
+		"#.###.#",
+		"#.....#",
+		"#######",
+	})
+	mg := ContractMaze(g, MazeSpec[rune]{
+		Passable: notWall,
+		Label: func(p pos.P2, r rune) (NodeID, bool) {
+			return NodeID(string(r)), r == 'A' || r == 'B'
+		},
+		Junctions: true,
+	})
+	nodes := []NodeID{"A", "B"}
+
+	if r, ok := HamiltonianPath(nodes, mg, "A", false); !ok || r.Cost != 4 {
+		t.Errorf("shortest = %v, %v, want 4", r, ok)
+	}
+	if r, ok := HamiltonianPath(nodes, mg, "A", true); !ok || r.Cost != 8 {
+		t.Errorf("longest = %v, %v, want 8", r, ok)
+	}
+}
+
+func TestHamiltonianCycle(t *testing.T) {
+	// 2015/13 sample, with each pair's happiness summed.
+	g := symmetricGraph(map[[2]NodeID]int{
+		{"Alice", "Bob"}:   54 + 83,
+		{"Alice", "Carol"}: -79 - 62,
+		{"Alice", "David"}: -2 + 46,
+		{"Bob", "Carol"}:   -7 + 60,
+		{"Bob", "David"}:   -63 - 7,
+		{"Carol", "David"}: 55 + 41,
+	})
+	nodes := []NodeID{"Alice", "Bob", "Carol", "David"}
+
+	r, ok := HamiltonianCycle(nodes, g, true)
+	if !ok || r.Cost != 330 || r.Nodes[0] != "Alice" {
+		t.Errorf("longest cycle = %v, %v, want 330 from Alice", r, ok)
+	}
+
+	// 2016/24 sample, where routes can pass through other numbers.
+	ducts := symmetricGraph(map[[2]NodeID]int{
+		{"0", "1"}: 2, {"0", "4"}: 2, {"1", "2"}: 6, {"2", "3"}: 2, {"3", "4"}: 8,
+	})
+	nums := []NodeID{"0", "1", "2", "3", "4"}
+	d := FloydWarshall(nums, ducts)
+	if r, ok := HamiltonianPath(nums, d, "0", false); !ok || r.Cost != 14 {
+		t.Errorf("shortest from 0 = %v, %v, want 14", r, ok)
+	}
+	if r, ok := HamiltonianCycle(nums, d, false); !ok || r.Cost != 20 {
+		t.Errorf("shortest cycle = %v, %v, want 20", r, ok)
+	}
+
+	// Without passing through, there's no way to visit everything.
+	disconnected := symmetricGraph(map[[2]NodeID]int{{"a", "b"}: 1, {"b", "c"}: 1})
+	if r, ok := HamiltonianCycle([]NodeID{"a", "b", "c"}, disconnected, false); ok {
+		t.Errorf("cycle on a path graph = %v, true, want false", r)
+	}
+}
+
+func TestOrienteering(t *testing.T) {
+	// 2022/16 sample.
+	type valve struct {
+		rate    int
+		tunnels []NodeID
+	}
+	valves := map[NodeID]valve{
+		"AA": {0, []NodeID{"DD", "II", "BB"}},
+		"BB": {13, []NodeID{"CC", "AA"}},
+		"CC": {2, []NodeID{"DD", "BB"}},
+		"DD": {20, []NodeID{"CC", "AA", "EE"}},
+		"EE": {3, []NodeID{"FF", "DD"}},
+		"FF": {0, []NodeID{"EE", "GG"}},
+		"GG": {0, []NodeID{"FF", "HH"}},
+		"HH": {22, []NodeID{"GG"}},
+		"II": {0, []NodeID{"AA", "JJ"}},
+		"JJ": {21, []NodeID{"II"}},
+	}
+
+	g := &testGraph{nodes: map[NodeID]map[NodeID]int{}}
+	all, useful := []NodeID{}, []NodeID{}
+	for id, v := range valves {
+		g.nodes[id] = map[NodeID]int{}
+		for _, to := range v.tunnels {
+			g.nodes[id][to] = 1
+		}
+		all = append(all, id)
+		if v.rate > 0 {
+			useful = append(useful, id)
+		}
+	}
+	d := FloydWarshall(all, g)
+
+	reward := func(id NodeID, remaining int) int {
+		return valves[id].rate * remaining
+	}
+
+	o := &Orienteering{Start: "AA", Budget: 30, VisitTime: 1, Reward: reward}
+	if got := o.Solve(useful, d).Best; got != 1651 {
+		t.Errorf("alone: Best = %v, want 1651", got)
+	}
+
+	// With an elephant, each taking a disjoint set of valves.
+	o.Budget = 26
+	res := o.Solve(useful, d)
+	best := 0
+	for mine, v1 := range res.BySet {
+		for theirs, v2 := range res.BySet {
+			if mine.Intersect(theirs).IsEmpty() {
+				best = max(best, v1+v2)
+			}
+		}
+	}
+	if best != 1707 {
+		t.Errorf("with elephant = %v, want 1707", best)
+	}
+}
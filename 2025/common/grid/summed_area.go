@@ -0,0 +1,79 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grid
+
+import (
+	"github.com/simmonmt/aoc/2025/common/area"
+	"github.com/simmonmt/aoc/2025/common/mtsmath"
+	"github.com/simmonmt/aoc/2025/common/pos"
+)
+
+// SummedArea is a summed-area table (2D prefix sums) for a numeric grid,
+// which answers rectangle sums in O(1).
+type SummedArea[T mtsmath.Number] struct {
+	w, h int
+	sums []T // (w+1)×(h+1); sums at x,y covers cells [0,x)×[0,y)
+}
+
+func NewSummedArea[T mtsmath.Number](g *Grid[T]) *SummedArea[T] {
+	s := &SummedArea[T]{
+		w:    g.Width(),
+		h:    g.Height(),
+		sums: make([]T, (g.Width()+1)*(g.Height()+1)),
+	}
+
+	g.Walk(func(p pos.P2, v T) {
+		s.set(p.X+1, p.Y+1, v+s.get(p.X, p.Y+1)+s.get(p.X+1, p.Y)-s.get(p.X, p.Y))
+	})
+	return s
+}
+
+func (s *SummedArea[T]) get(x, y int) T {
+	return s.sums[y*(s.w+1)+x]
+}
+
+func (s *SummedArea[T]) set(x, y int, v T) {
+	s.sums[y*(s.w+1)+x] = v
+}
+
+// Sum returns the sum of the cells in a (inclusive of both corners). The area
+// is clipped to the grid.
+func (s *SummedArea[T]) Sum(a area.Area2D) T {
+	x1, y1 := max(a.From.X, 0), max(a.From.Y, 0)
+	x2, y2 := min(a.To.X, s.w-1)+1, min(a.To.Y, s.h-1)+1
+	if x1 >= x2 || y1 >= y2 {
+		return 0
+	}
+	return s.get(x2, y2) - s.get(x1, y2) - s.get(x2, y1) + s.get(x1, y1)
+}
+
+// MaxWindow finds the k×k window with the largest sum, returning its top left
+// corner and the sum. Ties go to the window with the smallest Y, then the
+// smallest X. Returns false if the grid is smaller than k×k.
+func (s *SummedArea[T]) MaxWindow(k int) (pos.P2, T, bool) {
+	var best pos.P2
+	var bestSum T
+	found := false
+
+	for y := 0; y+k <= s.h; y++ {
+		for x := 0; x+k <= s.w; x++ {
+			sum := s.get(x+k, y+k) - s.get(x, y+k) - s.get(x+k, y) + s.get(x, y)
+			if !found || sum > bestSum {
+				best, bestSum, found = pos.P2{X: x, Y: y}, sum, true
+			}
+		}
+	}
+	return best, bestSum, found
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grid
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/area"
+	"github.com/simmonmt/aoc/2025/common/pos"
+)
+
+func TestSummedAreaSum(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	g := New[int](7, 5)
+	g.Walk(func(p pos.P2, _ int) {
+		g.Set(p, rng.Intn(21)-10)
+	})
+	s := NewSummedArea(g)
+
+	for i := 0; i < 200; i++ {
+		a := area.Area2D{
+			From: pos.P2{X: rng.Intn(9) - 1, Y: rng.Intn(7) - 1},
+			To:   pos.P2{X: rng.Intn(9) - 1, Y: rng.Intn(7) - 1},
+		}
+
+		want := 0
+		g.Walk(func(p pos.P2, v int) {
+			if p.X >= a.From.X && p.X <= a.To.X && p.Y >= a.From.Y && p.Y <= a.To.Y {
+				want += v
+			}
+		})
+
+		if got := s.Sum(a); got != want {
+			t.Fatalf("Sum(%v) = %v, want %v", a, got, want)
+		}
+	}
+}
+
+func TestSummedAreaMaxWindow(t *testing.T) {
+	// 2018/11: 300×300 fuel cells with 1-based coordinates.
+	powerGrid := func(serial int) *SummedArea[int] {
+		g := New[int](300, 300)
+		g.Walk(func(p pos.P2, _ int) {
+			x, y := p.X+1, p.Y+1
+			rackID := x + 10
+			power := (rackID*y + serial) * rackID
+			g.Set(p, (power/100)%10-5)
+		})
+		return NewSummedArea(g)
+	}
+
+	type TestCase struct {
+		serial int
+		want   pos.P2
+		sum    int
+	}
+
+	for _, tc := range []TestCase{{18, pos.P2{X: 32, Y: 44}, 29}, {42, pos.P2{X: 20, Y: 60}, 30}} {
+		p, sum, ok := powerGrid(tc.serial).MaxWindow(3)
+		if !ok || p != tc.want || sum != tc.sum {
+			t.Errorf("serial %v: MaxWindow(3) = %v, %v, %v, want %v, %v, true",
+				tc.serial, p, sum, ok, tc.want, tc.sum)
+		}
+	}
+
+	s := powerGrid(18)
+	bestK, bestP, bestSum := 0, pos.P2{}, 0
+	for k := 1; k <= 300; k++ {
+		if p, sum, _ := s.MaxWindow(k); k == 1 || sum > bestSum {
+			bestK, bestP, bestSum = k, p, sum
+		}
+	}
+	if bestK != 16 || bestP != (pos.P2{X: 89, Y: 268}) || bestSum != 113 {
+		t.Errorf("best any size = %v, %v, %v, want 16, 89,268, 113",
+			bestK, bestP, bestSum)
+	}
+
+	if _, _, ok := s.MaxWindow(301); ok {
+		t.Errorf("MaxWindow(301) = _, _, true, want false")
+	}
+}
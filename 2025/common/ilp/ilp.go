@@ -0,0 +1,285 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ilp is a small integer linear programming solver. It uses an exact
+// (rational) two-phase simplex for the LP relaxation and branch-and-bound to
+// find integer solutions. It's intended for the handful-of-variables problems
+// that show up in puzzles, not for anything large.
+package ilp
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/simmonmt/aoc/2025/common/mtsmath"
+)
+
+type Op int
+
+const (
+	LE Op = iota // <=
+	GE           // >=
+	EQ           // ==
+)
+
+func (o Op) String() string {
+	switch o {
+	case LE:
+		return "<="
+	case GE:
+		return ">="
+	case EQ:
+		return "=="
+	default:
+		panic("bad op")
+	}
+}
+
+type Status int
+
+const (
+	Optimal Status = iota
+	Infeasible
+	Unbounded
+	NodeLimit // branch-and-bound gave up; see SetMaxNodes
+)
+
+func (s Status) String() string {
+	switch s {
+	case Optimal:
+		return "optimal"
+	case Infeasible:
+		return "infeasible"
+	case Unbounded:
+		return "unbounded"
+	case NodeLimit:
+		return "node limit"
+	default:
+		panic("bad status")
+	}
+}
+
+type constraint struct {
+	coeffs []mtsmath.Rat
+	op     Op
+	rhs    mtsmath.Rat
+}
+
+func (c constraint) String() string {
+	return fmt.Sprintf("%v %v %v", c.coeffs, c.op, c.rhs)
+}
+
+// defaultMaxNodes is far more LP relaxations than any puzzle-sized problem
+// needs, while still giving up in seconds on one that can't be solved.
+const defaultMaxNodes = 100000
+
+// Problem describes an optimization over non-negative variables. Variables are
+// integers unless marked with SetContinuous. The default objective is to
+// minimize zero, which makes Solve a feasibility check.
+type Problem struct {
+	numVars     int
+	continuous  []bool
+	objective   []mtsmath.Rat
+	maximize    bool
+	constraints []constraint
+	maxNodes    int
+}
+
+func New(numVars int) *Problem {
+	return &Problem{
+		numVars:    numVars,
+		continuous: make([]bool, numVars),
+		objective:  make([]mtsmath.Rat, numVars),
+		maxNodes:   defaultMaxNodes,
+	}
+}
+
+// SetMaxNodes limits the number of LP relaxations branch-and-bound solves.
+// If the limit is reached, Solve returns NodeLimit.
+func (p *Problem) SetMaxNodes(n int) {
+	if n < 1 {
+		panic("bad node limit")
+	}
+	p.maxNodes = n
+}
+
+// SetContinuous removes the integrality requirement from variable v.
+func (p *Problem) SetContinuous(v int) {
+	p.continuous[v] = true
+}
+
+func (p *Problem) checkLen(coeffs []mtsmath.Rat) {
+	if len(coeffs) != p.numVars {
+		panic(fmt.Sprintf("got %d coeffs, want %d", len(coeffs), p.numVars))
+	}
+}
+
+// Minimize sets the objective to minimizing coeffs·x.
+func (p *Problem) Minimize(coeffs []int64) {
+	p.SetObjective(mtsmath.RatVector(coeffs), false)
+}
+
+// Maximize sets the objective to maximizing coeffs·x.
+func (p *Problem) Maximize(coeffs []int64) {
+	p.SetObjective(mtsmath.RatVector(coeffs), true)
+}
+
+func (p *Problem) SetObjective(coeffs []mtsmath.Rat, maximize bool) {
+	p.checkLen(coeffs)
+	p.objective = append([]mtsmath.Rat(nil), coeffs...)
+	p.maximize = maximize
+}
+
+// AddConstraint adds the constraint coeffs·x op rhs.
+func (p *Problem) AddConstraint(coeffs []int64, op Op, rhs int64) {
+	p.AddRatConstraint(mtsmath.RatVector(coeffs), op, mtsmath.RatFromInt(rhs))
+}
+
+func (p *Problem) AddRatConstraint(coeffs []mtsmath.Rat, op Op, rhs mtsmath.Rat) {
+	p.checkLen(coeffs)
+	p.constraints = append(p.constraints, constraint{
+		coeffs: append([]mtsmath.Rat(nil), coeffs...),
+		op:     op,
+		rhs:    rhs,
+	})
+}
+
+type Solution struct {
+	Status Status
+
+	// Value and X are the objective value and variable values. They're
+	// set if Optimal, and if NodeLimit when an integer solution (not
+	// necessarily the best) had been found before giving up.
+	Value mtsmath.Rat
+	X     []mtsmath.Rat
+}
+
+// hasNoIntegerSolution returns true if c is an equality over integer
+// variables that no integers can satisfy: scaled to integer coefficients, the
+// gcd of the coefficients must divide the right-hand side. The LP relaxation
+// can't see this, and branching on such a row never ends if its variables
+// aren't otherwise bounded.
+func (p *Problem) hasNoIntegerSolution(c constraint) bool {
+	if c.op != EQ {
+		return false
+	}
+
+	scale := new(big.Int).Set(c.rhs.Den())
+	for v, coeff := range c.coeffs {
+		if coeff.IsZero() {
+			continue
+		}
+		if p.continuous[v] {
+			return false
+		}
+		den := coeff.Den()
+		g := new(big.Int).GCD(nil, nil, scale, den)
+		scale.Mul(scale, new(big.Int).Quo(den, g))
+	}
+
+	scaled := func(r mtsmath.Rat) *big.Int {
+		n := new(big.Int).Mul(r.Num(), scale)
+		return n.Quo(n, r.Den())
+	}
+
+	g := new(big.Int)
+	for _, coeff := range c.coeffs {
+		g.GCD(nil, nil, g, scaled(coeff))
+	}
+	if g.Sign() == 0 {
+		return false // 0 == rhs; the LP will sort it out
+	}
+	return new(big.Int).Rem(scaled(c.rhs), g).Sign() != 0
+}
+
+// Solve finds an optimal solution, or gives up with NodeLimit if
+// branch-and-bound needs too many nodes. That can only happen when some
+// integer variable is unbounded.
+func (p *Problem) Solve() Solution {
+	for _, c := range p.constraints {
+		if p.hasNoIntegerSolution(c) {
+			return Solution{Status: Infeasible}
+		}
+	}
+
+	// solveLP always minimizes.
+	obj := p.objective
+	if p.maximize {
+		obj = make([]mtsmath.Rat, len(p.objective))
+		for i, c := range p.objective {
+			obj[i] = c.Neg()
+		}
+	}
+
+	var best *lpResult
+	nodes, gaveUp := 0, false
+	var branch func(cons []constraint) bool
+	branch = func(cons []constraint) bool {
+		if nodes >= p.maxNodes {
+			gaveUp = true
+			return true
+		}
+		nodes++
+
+		res := solveLP(p.numVars, cons, obj)
+		if res.status == Unbounded {
+			return false
+		}
+		if res.status == Infeasible {
+			return true
+		}
+		if best != nil && res.value.Cmp(best.value) >= 0 {
+			return true // can't beat what we already have
+		}
+
+		for v, val := range res.x {
+			if p.continuous[v] || val.IsInt() {
+				continue
+			}
+
+			coeffs := make([]mtsmath.Rat, p.numVars)
+			coeffs[v] = mtsmath.RatFromInt(1)
+
+			down := append(cons[:len(cons):len(cons)],
+				constraint{coeffs: coeffs, op: LE, rhs: val.Floor()})
+			up := append(cons[:len(cons):len(cons)],
+				constraint{coeffs: coeffs, op: GE, rhs: val.Ceil()})
+			return branch(down) && branch(up)
+		}
+
+		best = &res
+		return true
+	}
+
+	if !branch(p.constraints) {
+		return Solution{Status: Unbounded}
+	}
+
+	status := Optimal
+	if gaveUp {
+		status = NodeLimit
+	}
+	if best == nil {
+		if gaveUp {
+			return Solution{Status: NodeLimit}
+		}
+		return Solution{Status: Infeasible}
+	}
+
+	value := best.value
+	if p.maximize {
+		value = value.Neg()
+	}
+	return Solution{Status: status, Value: value, X: best.x}
+}
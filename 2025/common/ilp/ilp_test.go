@@ -0,0 +1,312 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilp
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/mtsmath"
+)
+
+func TestSolve(t *testing.T) {
+	type TestCase struct {
+		name       string
+		build      func() *Problem
+		wantStatus Status
+		wantValue  string
+		wantX      []int64
+	}
+
+	testCases := []TestCase{
+		TestCase{
+			name: "claw machine",
+			build: func() *Problem {
+				p := New(2)
+				p.Minimize([]int64{3, 1})
+				p.AddConstraint([]int64{94, 22}, EQ, 8400)
+				p.AddConstraint([]int64{34, 67}, EQ, 5400)
+				return p
+			},
+			wantStatus: Optimal,
+			wantValue:  "280",
+			wantX:      []int64{80, 40},
+		},
+		TestCase{
+			name: "no integer solution",
+			build: func() *Problem {
+				p := New(2)
+				p.Minimize([]int64{3, 1})
+				p.AddConstraint([]int64{26, 66}, EQ, 12748)
+				p.AddConstraint([]int64{67, 21}, EQ, 12176)
+				return p
+			},
+			wantStatus: Infeasible,
+		},
+		TestCase{
+			name: "button presses",
+			build: func() *Problem {
+				// Buttons increment counters {3}, {1,3}, {2},
+				// {2,3}, {0,2}, {0,1}; targets {3,5,4,7}.
+				p := New(6)
+				p.Minimize([]int64{1, 1, 1, 1, 1, 1})
+				p.AddConstraint([]int64{0, 0, 0, 0, 1, 1}, EQ, 3)
+				p.AddConstraint([]int64{0, 1, 0, 0, 0, 1}, EQ, 5)
+				p.AddConstraint([]int64{0, 0, 1, 1, 1, 0}, EQ, 4)
+				p.AddConstraint([]int64{1, 1, 0, 1, 0, 0}, EQ, 7)
+				return p
+			},
+			wantStatus: Optimal,
+			wantValue:  "10",
+		},
+		TestCase{
+			name: "maximize with bounds",
+			build: func() *Problem {
+				p := New(2)
+				p.Maximize([]int64{1, 1})
+				p.AddConstraint([]int64{2, 2}, LE, 7)
+				return p
+			},
+			wantStatus: Optimal,
+			wantValue:  "3",
+		},
+		TestCase{
+			name: "continuous",
+			build: func() *Problem {
+				p := New(2)
+				p.SetContinuous(1)
+				p.Maximize([]int64{1, 1})
+				p.AddConstraint([]int64{2, 2}, LE, 7)
+				return p
+			},
+			wantStatus: Optimal,
+			wantValue:  "7/2",
+		},
+		TestCase{
+			name: "negative rhs",
+			build: func() *Problem {
+				p := New(1)
+				p.Minimize([]int64{1})
+				p.AddConstraint([]int64{-1}, LE, -3)
+				return p
+			},
+			wantStatus: Optimal,
+			wantValue:  "3",
+			wantX:      []int64{3},
+		},
+		TestCase{
+			name: "infeasible",
+			build: func() *Problem {
+				p := New(1)
+				p.AddConstraint([]int64{1}, GE, 3)
+				p.AddConstraint([]int64{1}, LE, 2)
+				return p
+			},
+			wantStatus: Infeasible,
+		},
+		TestCase{
+			name: "unbounded",
+			build: func() *Problem {
+				p := New(2)
+				p.Maximize([]int64{1, 0})
+				p.AddConstraint([]int64{0, 1}, LE, 2)
+				return p
+			},
+			wantStatus: Unbounded,
+		},
+		TestCase{
+			name: "odd equals even",
+			build: func() *Problem {
+				p := New(2)
+				p.AddConstraint([]int64{2, -2}, EQ, 1)
+				return p
+			},
+			wantStatus: Infeasible,
+		},
+		TestCase{
+			name: "odd equals even maximized",
+			build: func() *Problem {
+				p := New(2)
+				p.Maximize([]int64{1, 0})
+				p.AddConstraint([]int64{2, -2}, EQ, 1)
+				return p
+			},
+			wantStatus: Infeasible,
+		},
+		TestCase{
+			name: "fractional coefficients",
+			build: func() *Problem {
+				// Scaled by 6, this is 3x - 2y == 1, which
+				// the gcd check must not reject.
+				p := New(2)
+				p.Minimize([]int64{1, 1})
+				p.AddRatConstraint([]mtsmath.Rat{
+					mtsmath.NewRat(1, 2), mtsmath.NewRat(-1, 3),
+				}, EQ, mtsmath.NewRat(1, 6))
+				return p
+			},
+			wantStatus: Optimal,
+			wantValue:  "2",
+			wantX:      []int64{1, 1},
+		},
+		TestCase{
+			name: "node limit",
+			build: func() *Problem {
+				// The same as odd equals even, but as a pair
+				// of inequalities that the gcd check can't
+				// see through.
+				p := New(2)
+				p.AddConstraint([]int64{2, -2}, GE, 1)
+				p.AddConstraint([]int64{2, -2}, LE, 1)
+				p.SetMaxNodes(100)
+				return p
+			},
+			wantStatus: NodeLimit,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sol := tc.build().Solve()
+			if sol.Status != tc.wantStatus {
+				t.Fatalf("Solve status = %v, want %v",
+					sol.Status, tc.wantStatus)
+			}
+			if sol.Status != Optimal {
+				return
+			}
+			if got := sol.Value.String(); got != tc.wantValue {
+				t.Errorf("Solve value = %v, want %v", got, tc.wantValue)
+			}
+			if tc.wantX != nil {
+				got, ok := mtsmath.IntVector(sol.X)
+				if !ok {
+					t.Fatalf("Solve x = %v, want ints", sol.X)
+				}
+				for i := range got {
+					if got[i] != tc.wantX[i] {
+						t.Errorf("Solve x = %v, want %v",
+							got, tc.wantX)
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+// bruteForce enumerates every point in [0,limit]^numVars and returns the best
+// objective value, or false if none satisfy the constraints.
+func bruteForce(numVars, limit int, obj []int64, maximize bool, cons [][]int64, ops []Op) (int64, bool) {
+	x := make([]int64, numVars)
+	var best int64
+	found := false
+
+	var walk func(v int)
+	walk = func(v int) {
+		if v < numVars {
+			for i := 0; i <= limit; i++ {
+				x[v] = int64(i)
+				walk(v + 1)
+			}
+			return
+		}
+
+		for i, c := range cons {
+			lhs := int64(0)
+			for j := 0; j < numVars; j++ {
+				lhs += c[j] * x[j]
+			}
+			rhs := c[numVars]
+			if (ops[i] == LE && lhs > rhs) || (ops[i] == GE && lhs < rhs) || (ops[i] == EQ && lhs != rhs) {
+				return
+			}
+		}
+
+		val := int64(0)
+		for j := range x {
+			val += obj[j] * x[j]
+		}
+		if !found || (maximize && val > best) || (!maximize && val < best) {
+			best, found = val, true
+		}
+	}
+	walk(0)
+	return best, found
+}
+
+func TestSolveAgainstBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const limit = 6
+
+	for i := 0; i < 200; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			numVars := 2 + r.Intn(2)
+			p := New(numVars)
+
+			obj := make([]int64, numVars)
+			for j := range obj {
+				obj[j] = int64(r.Intn(11) - 5)
+			}
+			maximize := r.Intn(2) == 0
+			if maximize {
+				p.Maximize(obj)
+			} else {
+				p.Minimize(obj)
+			}
+
+			// Box every variable so brute force is exhaustive.
+			for j := 0; j < numVars; j++ {
+				coeffs := make([]int64, numVars)
+				coeffs[j] = 1
+				p.AddConstraint(coeffs, LE, limit)
+			}
+
+			cons := [][]int64{}
+			ops := []Op{}
+			for j := 1 + r.Intn(3); j > 0; j-- {
+				c := make([]int64, numVars+1)
+				for k := 0; k < numVars; k++ {
+					c[k] = int64(r.Intn(9) - 4)
+				}
+				c[numVars] = int64(r.Intn(21) - 5)
+				op := Op(r.Intn(3))
+
+				cons = append(cons, c)
+				ops = append(ops, op)
+				p.AddConstraint(c[:numVars], op, c[numVars])
+			}
+
+			want, wantFound := bruteForce(numVars, limit, obj, maximize, cons, ops)
+			sol := p.Solve()
+
+			if !wantFound {
+				if sol.Status != Infeasible {
+					t.Errorf("Solve = %v, want infeasible",
+						sol.Status)
+				}
+				return
+			}
+
+			if sol.Status != Optimal {
+				t.Fatalf("Solve = %v, want optimal", sol.Status)
+			}
+			if got, ok := sol.Value.Int64(); !ok || got != want {
+				t.Errorf("Solve value = %v, want %v", sol.Value, want)
+			}
+		})
+	}
+}
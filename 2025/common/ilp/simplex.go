@@ -0,0 +1,238 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilp
+
+import "github.com/simmonmt/aoc/2025/common/mtsmath"
+
+type lpResult struct {
+	status Status
+	value  mtsmath.Rat
+	x      []mtsmath.Rat
+}
+
+// tableau is a dense simplex tableau. Each row is one constraint, with the
+// right-hand side in the last column. basis[i] is the column currently basic
+// in row i.
+type tableau struct {
+	rows  [][]mtsmath.Rat
+	basis []int
+}
+
+func (t *tableau) rhsCol() int {
+	return len(t.rows[0]) - 1
+}
+
+func (t *tableau) pivot(row, col int) {
+	pr := t.rows[row]
+	inv := pr[col].Inv()
+	for c := range pr {
+		pr[c] = pr[c].Mul(inv)
+	}
+
+	for r, tr := range t.rows {
+		if r == row || tr[col].IsZero() {
+			continue
+		}
+		factor := tr[col]
+		for c := range tr {
+			if !pr[c].IsZero() {
+				tr[c] = tr[c].Sub(factor.Mul(pr[c]))
+			}
+		}
+	}
+
+	t.basis[row] = col
+}
+
+// minimize runs simplex iterations minimizing cost·x from the current (feasible)
+// basis, only allowing columns below numAllowed to enter. Bland's rule is used
+// for both entering and leaving choices, which guarantees termination. Returns
+// false if the objective is unbounded.
+func (t *tableau) minimize(cost []mtsmath.Rat, numAllowed int) bool {
+	rhs := t.rhsCol()
+	for {
+		enter := -1
+		for j := 0; j < numAllowed; j++ {
+			reduced := cost[j]
+			for i, b := range t.basis {
+				if !cost[b].IsZero() && !t.rows[i][j].IsZero() {
+					reduced = reduced.Sub(cost[b].Mul(t.rows[i][j]))
+				}
+			}
+			if reduced.Sign() < 0 {
+				enter = j
+				break
+			}
+		}
+		if enter < 0 {
+			return true // optimal
+		}
+
+		leave := -1
+		var bestRatio mtsmath.Rat
+		for i, row := range t.rows {
+			if row[enter].Sign() <= 0 {
+				continue
+			}
+			ratio := row[rhs].Div(row[enter])
+			if leave < 0 {
+				leave, bestRatio = i, ratio
+				continue
+			}
+			if c := ratio.Cmp(bestRatio); c < 0 || (c == 0 && t.basis[i] < t.basis[leave]) {
+				leave, bestRatio = i, ratio
+			}
+		}
+		if leave < 0 {
+			return false // unbounded
+		}
+
+		t.pivot(leave, enter)
+	}
+}
+
+func (t *tableau) value(cost []mtsmath.Rat) mtsmath.Rat {
+	var v mtsmath.Rat
+	for i, b := range t.basis {
+		v = v.Add(cost[b].Mul(t.rows[i][t.rhsCol()]))
+	}
+	return v
+}
+
+// solveLP minimizes obj·x subject to cons and x >= 0 using the two-phase
+// simplex method.
+func solveLP(numVars int, cons []constraint, obj []mtsmath.Rat) lpResult {
+	// Normalize so every right-hand side is non-negative, then count the
+	// slack/surplus and artificial columns we'll need.
+	norm := make([]constraint, len(cons))
+	numSlack, numArt := 0, 0
+	for i, c := range cons {
+		if c.rhs.Sign() < 0 {
+			neg := make([]mtsmath.Rat, len(c.coeffs))
+			for j, v := range c.coeffs {
+				neg[j] = v.Neg()
+			}
+			op := c.op
+			if op == LE {
+				op = GE
+			} else if op == GE {
+				op = LE
+			}
+			c = constraint{coeffs: neg, op: op, rhs: c.rhs.Neg()}
+		}
+		norm[i] = c
+
+		if c.op != EQ {
+			numSlack++
+		}
+		if c.op != LE {
+			numArt++
+		}
+	}
+
+	// Columns: originals, then slack/surplus, then artificials, then rhs.
+	slackStart := numVars
+	artStart := slackStart + numSlack
+	numCols := artStart + numArt + 1
+
+	t := &tableau{
+		rows:  make([][]mtsmath.Rat, len(norm)),
+		basis: make([]int, len(norm)),
+	}
+
+	one := mtsmath.RatFromInt(1)
+	slack, art := slackStart, artStart
+	for i, c := range norm {
+		row := make([]mtsmath.Rat, numCols)
+		copy(row, c.coeffs)
+		row[numCols-1] = c.rhs
+
+		switch c.op {
+		case LE:
+			row[slack] = one
+			t.basis[i] = slack
+			slack++
+		case GE:
+			row[slack] = one.Neg()
+			slack++
+			row[art] = one
+			t.basis[i] = art
+			art++
+		case EQ:
+			row[art] = one
+			t.basis[i] = art
+			art++
+		}
+		t.rows[i] = row
+	}
+
+	// Phase 1: drive the artificials to zero.
+	if numArt > 0 {
+		cost := make([]mtsmath.Rat, numCols-1)
+		for j := artStart; j < numCols-1; j++ {
+			cost[j] = one
+		}
+		t.minimize(cost, numCols-1)
+		if t.value(cost).Sign() > 0 {
+			return lpResult{status: Infeasible}
+		}
+
+		// Any artificial still in the basis is at zero. Pivot it out if
+		// possible; otherwise its row is redundant.
+		for i := 0; i < len(t.rows); i++ {
+			if t.basis[i] < artStart {
+				continue
+			}
+			col := -1
+			for j := 0; j < artStart; j++ {
+				if !t.rows[i][j].IsZero() {
+					col = j
+					break
+				}
+			}
+			if col >= 0 {
+				t.pivot(i, col)
+				continue
+			}
+			t.rows = append(t.rows[:i], t.rows[i+1:]...)
+			t.basis = append(t.basis[:i], t.basis[i+1:]...)
+			i--
+		}
+	}
+
+	// Phase 2: the real objective, with artificials barred from entering.
+	cost := make([]mtsmath.Rat, numCols-1)
+	copy(cost, obj)
+	if len(t.rows) > 0 {
+		if !t.minimize(cost, artStart) {
+			return lpResult{status: Unbounded}
+		}
+	} else {
+		for _, c := range obj {
+			if c.Sign() < 0 {
+				return lpResult{status: Unbounded}
+			}
+		}
+	}
+
+	x := make([]mtsmath.Rat, numVars)
+	for i, b := range t.basis {
+		if b < numVars {
+			x[b] = t.rows[i][numCols-1]
+		}
+	}
+
+	return lpResult{status: Optimal, value: t.value(cost), x: x}
+}
@@ -109,18 +109,24 @@ func Init(log bool) {
 	slog.SetDefault(slog.New(newLogHandler(os.Stderr, level)))
 }
 
-func doLog(level slog.Level, format string, args ...any) {
+// logAt logs with the location of the caller skip frames up the stack, as
+// counted by runtime.Callers.
+func logAt(skip int, level slog.Level, format string, args ...any) {
 	handler := slog.Default().Handler()
 	if !handler.Enabled(context.Background(), level) {
 		return
 	}
 
 	var pcs [1]uintptr
-	runtime.Callers(3, pcs[:]) // skip [Callers, doLog, Infof]
+	runtime.Callers(skip, pcs[:])
 	r := slog.NewRecord(time.Now(), level, fmt.Sprintf(format, args...), pcs[0])
 	_ = handler.Handle(context.Background(), r)
 }
 
+func doLog(level slog.Level, format string, args ...any) {
+	logAt(4, level, format, args...) // skip [Callers, logAt, doLog, Infof]
+}
+
 func Infof(format string, args ...any) {
 	doLog(slog.LevelInfo, format, args...)
 }
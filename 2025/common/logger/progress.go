@@ -0,0 +1,153 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// checkEvery is how many units of work go by between looks at the clock, so
+// that Add stays cheap in tight loops.
+const checkEvery = 1024
+
+type gauge struct {
+	name string
+	fn   func() int64
+}
+
+// Progress reports on a long-running loop. The loop calls Add as it works,
+// and at most once per interval (a second by default) Progress logs the
+// amount done, the rate, any gauges (frontier size, best so far, ...), and,
+// if the total is known, an ETA. When logging is disabled Add does almost
+// nothing. Add may be called concurrently.
+type Progress struct {
+	name     string
+	unit     string
+	total    int64
+	interval time.Duration
+	gauges   []gauge
+	now      func() time.Time
+
+	enabled   bool
+	done      atomic.Int64
+	nextCheck atomic.Int64
+
+	mu       sync.Mutex // guards the fields below, and reporting
+	start    time.Time
+	last     time.Time
+	reported bool
+}
+
+// NewProgress returns a reporter whose lines start with name, and which
+// counts work in units (e.g. "states").
+func NewProgress(name, unit string) *Progress {
+	p := &Progress{
+		name:     name,
+		unit:     unit,
+		interval: time.Second,
+		now:      time.Now,
+		enabled:  slog.Default().Handler().Enabled(context.Background(), slog.LevelInfo),
+	}
+	p.start = p.now()
+	p.last = p.start
+	return p
+}
+
+// SetTotal sets the expected amount of work, which enables the ETA.
+func (p *Progress) SetTotal(total int64) {
+	p.total = total
+}
+
+func (p *Progress) SetInterval(interval time.Duration) {
+	p.interval = interval
+}
+
+// Gauge adds a value to each report. fn is only called when reporting, from
+// whichever goroutine's Add triggered the report, so it must be safe to call
+// concurrently with the loop if Add is.
+func (p *Progress) Gauge(name string, fn func() int64) {
+	p.gauges = append(p.gauges, gauge{name, fn})
+}
+
+// Add records n more units of work done, and reports if it's been long
+// enough since the last report.
+func (p *Progress) Add(n int64) {
+	if !p.enabled {
+		return
+	}
+	done := p.done.Add(n)
+	if next := p.nextCheck.Load(); done < next || !p.nextCheck.CompareAndSwap(next, done+checkEvery) {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	if now.Sub(p.last) < p.interval {
+		return
+	}
+	p.last = now
+	p.reported = true
+	p.report(now, "")
+}
+
+// Done logs a final summary, but only if the loop ran long enough to have
+// reported progress; short searches stay quiet.
+func (p *Progress) Done() {
+	if !p.enabled {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.reported {
+		return
+	}
+	p.report(p.now(), "finished ")
+}
+
+func (p *Progress) report(now time.Time, prefix string) {
+	done := p.done.Load()
+	elapsed := now.Sub(p.start)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s%d %s", p.name, prefix, done, p.unit)
+	if p.total > 0 {
+		fmt.Fprintf(&b, "/%d (%.1f%%)", p.total, 100*float64(done)/float64(p.total))
+	}
+
+	rate := 0.0
+	if secs := elapsed.Seconds(); secs > 0 {
+		rate = float64(done) / secs
+	}
+	fmt.Fprintf(&b, " in %v, %.0f/s", elapsed.Round(time.Millisecond), rate)
+	if p.total > 0 && prefix == "" && rate > 0 && done < p.total {
+		eta := time.Duration(float64(p.total-done) / rate * float64(time.Second))
+		fmt.Fprintf(&b, ", ETA %v", eta.Round(time.Second))
+	}
+
+	for _, g := range p.gauges {
+		fmt.Fprintf(&b, " %s=%d", g.name, g.fn())
+	}
+
+	// Callers, logAt, report, Add/Done
+	logAt(4, slog.LevelInfo, "%s", b.String())
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	old := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(old) })
+
+	buf := &bytes.Buffer{}
+	slog.SetDefault(slog.New(newLogHandler(buf, slog.LevelDebug)))
+	return buf
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestProgress(clock *fakeClock) *Progress {
+	p := NewProgress("test", "states")
+	p.now = clock.Now
+	p.start, p.last = clock.now, clock.now
+	return p
+}
+
+func TestProgress(t *testing.T) {
+	buf := captureLogs(t)
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+
+	p := newTestProgress(clock)
+	p.SetTotal(10000)
+	best := int64(0)
+	p.Gauge("best", func() int64 { return best })
+
+	// Less than the interval has passed, so nothing gets reported.
+	p.Add(1000)
+	if buf.Len() != 0 {
+		t.Errorf("early report: %q", buf.String())
+	}
+
+	clock.now = clock.now.Add(2 * time.Second)
+	best = 42
+	p.Add(1500)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines, want 1: %q", len(lines), buf.String())
+	}
+	want := "test: 2500 states/10000 (25.0%) in 2s, 1250/s, ETA 6s best=42"
+	if !strings.HasSuffix(lines[0], want) {
+		t.Errorf("report = %q, want suffix %q", lines[0], want)
+	}
+	if !strings.Contains(lines[0], "progress_test.go") {
+		t.Errorf("report = %q, want caller's location", lines[0])
+	}
+
+	// Ticks between clock checks don't report, even if the interval has
+	// passed.
+	buf.Reset()
+	clock.now = clock.now.Add(2 * time.Second)
+	p.Add(1)
+	if buf.Len() != 0 {
+		t.Errorf("report between checks: %q", buf.String())
+	}
+
+	buf.Reset()
+	p.Done()
+	want = "test: finished 2501 states/10000 (25.0%) in 4s, 625/s best=42"
+	if got := strings.TrimSpace(buf.String()); !strings.HasSuffix(got, want) {
+		t.Errorf("Done() = %q, want suffix %q", got, want)
+	}
+}
+
+func TestProgressQuiet(t *testing.T) {
+	buf := captureLogs(t)
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+
+	// A loop that finishes within the interval says nothing at all.
+	p := newTestProgress(clock)
+	p.Add(5000)
+	p.Done()
+	if buf.Len() != 0 {
+		t.Errorf("quiet progress logged %q", buf.String())
+	}
+
+	// Nor does one that runs with logging disabled.
+	Init(false)
+	p = newTestProgress(clock)
+	clock.now = clock.now.Add(time.Minute)
+	p.Add(5000)
+	p.Done()
+	if buf.Len() != 0 {
+		t.Errorf("disabled progress logged %q", buf.String())
+	}
+}
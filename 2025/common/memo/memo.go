@@ -0,0 +1,145 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memo memoizes recursive functions of a comparable key, so dynamic
+// programming solutions don't have to thread a cache through every call.
+package memo
+
+import (
+	"container/list"
+
+	"github.com/simmonmt/aoc/2025/common/logger"
+)
+
+type lruEntry[K comparable, V any] struct {
+	key K
+	val V
+}
+
+type Memo[K comparable, V any] struct {
+	fn func(recurse func(K) V, key K) V
+
+	// Unbounded memos use cache. Bounded memos use lru and order instead,
+	// with the most recently used entry at the front of order.
+	cache      map[K]V
+	lru        map[K]*list.Element
+	order      *list.List
+	maxEntries int
+
+	hits, misses, evictions int
+}
+
+// New returns a memoizer for fn. fn must make its recursive calls through the
+// recurse function it's passed rather than calling itself directly, so that
+// they're memoized too.
+func New[K comparable, V any](fn func(recurse func(K) V, key K) V) *Memo[K, V] {
+	return &Memo[K, V]{
+		fn:    fn,
+		cache: map[K]V{},
+	}
+}
+
+// Func is a shorthand for New(fn).Get, for when the statistics aren't needed.
+func Func[K comparable, V any](fn func(recurse func(K) V, key K) V) func(K) V {
+	return New(fn).Get
+}
+
+// SetMaxEntries bounds the memo to at most n entries, evicting the least
+// recently used when full. It must be called before the first Get.
+func (m *Memo[K, V]) SetMaxEntries(n int) {
+	if m.hits+m.misses > 0 {
+		panic("already in use")
+	}
+	if n <= 0 {
+		panic("bad max")
+	}
+
+	m.maxEntries = n
+	m.cache = nil
+	m.lru = map[K]*list.Element{}
+	m.order = list.New()
+}
+
+func (m *Memo[K, V]) lookup(key K) (V, bool) {
+	if m.cache != nil {
+		v, found := m.cache[key]
+		return v, found
+	}
+
+	elem, found := m.lru[key]
+	if !found {
+		var zero V
+		return zero, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry[K, V]).val, true
+}
+
+func (m *Memo[K, V]) store(key K, val V) {
+	if m.cache != nil {
+		m.cache[key] = val
+		return
+	}
+
+	if elem, found := m.lru[key]; found {
+		// A deeper recursive call computed this key too.
+		elem.Value.(*lruEntry[K, V]).val = val
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	for len(m.lru) >= m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.lru, oldest.Value.(*lruEntry[K, V]).key)
+		m.evictions++
+	}
+	m.lru[key] = m.order.PushFront(&lruEntry[K, V]{key: key, val: val})
+}
+
+// Get returns fn(key), computing it only if it isn't already memoized.
+func (m *Memo[K, V]) Get(key K) V {
+	if v, found := m.lookup(key); found {
+		m.hits++
+		return v
+	}
+
+	m.misses++
+	v := m.fn(m.Get, key)
+	m.store(key, v)
+	return v
+}
+
+func (m *Memo[K, V]) Len() int {
+	if m.cache != nil {
+		return len(m.cache)
+	}
+	return len(m.lru)
+}
+
+// Stats returns the number of cache hits and misses, and the number of entries
+// evicted to stay within the bound set by SetMaxEntries.
+func (m *Memo[K, V]) Stats() (hits, misses, evictions int) {
+	return m.hits, m.misses, m.evictions
+}
+
+// LogStats logs the memo statistics, prefixed by name.
+func (m *Memo[K, V]) LogStats(name string) {
+	rate := 0.0
+	if total := m.hits + m.misses; total > 0 {
+		rate = 100 * float64(m.hits) / float64(total)
+	}
+	logger.Infof("%s: %d hits, %d misses (%.1f%% hit rate), %d entries, %d evictions",
+		name, m.hits, m.misses, rate, m.Len(), m.evictions)
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memo
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/logger"
+)
+
+func fib(recurse func(int) int64, n int) int64 {
+	if n < 2 {
+		return int64(n)
+	}
+	return recurse(n-1) + recurse(n-2)
+}
+
+func TestFib(t *testing.T) {
+	m := New(fib)
+	if got, want := m.Get(90), int64(2880067194370816120); got != want {
+		t.Errorf("fib(90) = %v, want %v", got, want)
+	}
+
+	// Each of 0..90 is computed once. Each of 2..90 makes two recursive
+	// calls, of which only the first misses.
+	hits, misses, evictions := m.Stats()
+	if hits != 88 || misses != 91 || evictions != 0 {
+		t.Errorf("Stats() = %v, %v, %v, want 88, 91, 0",
+			hits, misses, evictions)
+	}
+
+	m.Get(90)
+	if hits, _, _ := m.Stats(); hits != 89 {
+		t.Errorf("hits after repeat = %v, want 89", hits)
+	}
+
+	m.LogStats("fib")
+}
+
+func TestBounded(t *testing.T) {
+	m := New(fib)
+	m.SetMaxEntries(3)
+	if got, want := m.Get(90), int64(2880067194370816120); got != want {
+		t.Errorf("fib(90) = %v, want %v", got, want)
+	}
+	if got := m.Len(); got != 3 {
+		t.Errorf("Len() = %v, want 3", got)
+	}
+	if _, _, evictions := m.Stats(); evictions == 0 {
+		t.Errorf("evictions = 0, want >0")
+	}
+}
+
+func TestTowels(t *testing.T) {
+	// 2024/19 sample
+	towels := []string{"r", "wr", "b", "g", "bwu", "rb", "gb", "br"}
+	designs := map[string]int{
+		"brwrr": 2, "bggr": 1, "gbbr": 4, "rrbgbr": 6,
+		"ubwu": 0, "bwurrg": 1, "brgr": 2, "bbrwb": 0,
+	}
+
+	count := Func(func(recurse func(string) int, design string) int {
+		if design == "" {
+			return 1
+		}
+		num := 0
+		for _, towel := range towels {
+			if rest, found := strings.CutPrefix(design, towel); found {
+				num += recurse(rest)
+			}
+		}
+		return num
+	})
+
+	for design, want := range designs {
+		if got := count(design); got != want {
+			t.Errorf("count(%v) = %v, want %v", design, got, want)
+		}
+	}
+}
+
+func TestMain(m *testing.M) {
+	logger.Init(true)
+	os.Exit(m.Run())
+}
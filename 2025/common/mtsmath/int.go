@@ -0,0 +1,154 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtsmath
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Int is an integer that's stored as an int64 while it fits, and is
+// transparently promoted to a big.Int when an operation would overflow (and
+// demoted again when the result fits). The zero value is 0.
+//
+// Ints are immutable. Because big-backed values hold a pointer, use Equal
+// rather than == to compare them.
+type Int struct {
+	v int64
+	b *big.Int
+}
+
+func NewInt(v int64) Int {
+	if v == math.MinInt64 {
+		return Int{b: big.NewInt(v)}
+	}
+	return Int{v: v}
+}
+
+// IntFromBig returns an Int with the value of v. v is not retained.
+func IntFromBig(v *big.Int) Int {
+	return intFromBig(new(big.Int).Set(v))
+}
+
+// intFromBig is IntFromBig without the copy, for values we own.
+func intFromBig(v *big.Int) Int {
+	if v.IsInt64() && v.Int64() != math.MinInt64 {
+		return Int{v: v.Int64()}
+	}
+	return Int{b: v}
+}
+
+func (i Int) toBig() *big.Int {
+	if i.b != nil {
+		return i.b
+	}
+	return big.NewInt(i.v)
+}
+
+func (i Int) Add(o Int) Int {
+	if i.b == nil && o.b == nil {
+		c := overflowChecker{}
+		if r := c.add(i.v, o.v); !c.overflow {
+			return Int{v: r}
+		}
+	}
+	return intFromBig(new(big.Int).Add(i.toBig(), o.toBig()))
+}
+
+func (i Int) Sub(o Int) Int {
+	return i.Add(o.Neg())
+}
+
+func (i Int) Mul(o Int) Int {
+	if i.b == nil && o.b == nil {
+		c := overflowChecker{}
+		if r := c.mul(i.v, o.v); !c.overflow {
+			return Int{v: r}
+		}
+	}
+	return intFromBig(new(big.Int).Mul(i.toBig(), o.toBig()))
+}
+
+func (i Int) Neg() Int {
+	if i.b != nil {
+		return intFromBig(new(big.Int).Neg(i.b))
+	}
+	return Int{v: -i.v}
+}
+
+// Mod returns i mod m in the range [0, m). m must be positive.
+func (i Int) Mod(m int64) Int {
+	if i.b == nil {
+		return Int{v: Mod(i.v, m)}
+	}
+	return intFromBig(new(big.Int).Mod(i.b, big.NewInt(m)))
+}
+
+// Cmp returns -1, 0, or 1 depending on whether i is less than, equal to, or
+// greater than o.
+func (i Int) Cmp(o Int) int {
+	if i.b == nil && o.b == nil {
+		switch {
+		case i.v < o.v:
+			return -1
+		case i.v > o.v:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return i.toBig().Cmp(o.toBig())
+}
+
+func (i Int) Equal(o Int) bool {
+	return i.Cmp(o) == 0
+}
+
+func (i Int) Sign() int {
+	return i.Cmp(Int{})
+}
+
+func (i Int) IsZero() bool {
+	return i.b == nil && i.v == 0
+}
+
+// Int64 returns the value of i if it fits in an int64.
+func (i Int) Int64() (int64, bool) {
+	if i.b != nil {
+		return 0, false
+	}
+	return i.v, true
+}
+
+// Big returns the value of i as a newly-allocated big.Int.
+func (i Int) Big() *big.Int {
+	return new(big.Int).Set(i.toBig())
+}
+
+// Rat returns the value of i as a Rat.
+func (i Int) Rat() Rat {
+	if i.b != nil {
+		return ratFromBig(new(big.Rat).SetInt(i.b))
+	}
+	return RatFromInt(i.v)
+}
+
+func (i Int) String() string {
+	if i.b != nil {
+		return i.b.String()
+	}
+	return fmt.Sprint(i.v)
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtsmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInt(t *testing.T) {
+	type TestCase struct {
+		name string
+		got  Int
+		want string
+	}
+
+	huge := NewInt(math.MaxInt64).Mul(NewInt(4))
+
+	testCases := []TestCase{
+		TestCase{"zero value", Int{}, "0"},
+		TestCase{"add", NewInt(3).Add(NewInt(4)), "7"},
+		TestCase{"sub", NewInt(3).Sub(NewInt(4)), "-1"},
+		TestCase{"mul", NewInt(-3).Mul(NewInt(4)), "-12"},
+		TestCase{"add overflow", NewInt(math.MaxInt64).Add(NewInt(1)), "9223372036854775808"},
+		TestCase{"mul overflow", huge, "36893488147419103228"},
+		TestCase{"neg big", huge.Neg(), "-36893488147419103228"},
+		TestCase{"min int", NewInt(math.MinInt64), "-9223372036854775808"},
+		TestCase{"mod", NewInt(-7).Mod(5), "3"},
+		TestCase{"mod big", huge.Mod(1000), "228"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.got.String(); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	back := huge.Sub(NewInt(math.MaxInt64).Mul(NewInt(3)))
+	if v, ok := back.Int64(); !ok || v != math.MaxInt64 {
+		t.Errorf("demote = %v, %v, want %v, true", v, ok, int64(math.MaxInt64))
+	}
+
+	if huge.Cmp(NewInt(1)) != 1 || huge.Neg().Cmp(NewInt(1)) != -1 || !huge.Equal(huge.Add(Int{})) {
+		t.Errorf("Cmp mismatch")
+	}
+	if huge.Sign() != 1 || (Int{}).Sign() != 0 || !(Int{}).IsZero() {
+		t.Errorf("Sign mismatch")
+	}
+	if got := huge.Rat().Div(RatFromInt(4)).String(); got != "9223372036854775807" {
+		t.Errorf("Rat() = %v", got)
+	}
+}
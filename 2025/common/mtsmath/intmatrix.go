@@ -0,0 +1,223 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtsmath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IntMatrix is a dense matrix of Ints, optionally reduced modulo some m.
+// Without a modulus, entries are promoted to big integers as needed, so
+// raising a matrix to a huge power gives exact (if large) results.
+// Multiplication skips zero entries, so sparse matrices (like most
+// transition matrices) are cheap to multiply despite the dense storage.
+type IntMatrix struct {
+	rows, cols int
+	cells      []Int
+	mod        int64 // 0 means no modulus
+}
+
+func NewIntMatrix(rows, cols int) *IntMatrix {
+	return &IntMatrix{rows: rows, cols: cols, cells: make([]Int, rows*cols)}
+}
+
+func IdentityIntMatrix(n int) *IntMatrix {
+	m := NewIntMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.cells[i*n+i] = NewInt(1)
+	}
+	return m
+}
+
+// IntMatrixFrom returns a matrix with the given rows, which must all be the
+// same length.
+func IntMatrixFrom(in [][]int64) *IntMatrix {
+	cols := 0
+	if len(in) > 0 {
+		cols = len(in[0])
+	}
+
+	m := NewIntMatrix(len(in), cols)
+	for r, row := range in {
+		if len(row) != cols {
+			panic(fmt.Sprintf("row %d has %d cols, want %d", r, len(row), cols))
+		}
+		for c, v := range row {
+			m.cells[r*cols+c] = NewInt(v)
+		}
+	}
+	return m
+}
+
+// WithMod returns a copy of m whose entries (and the entries of any product
+// it's part of) are reduced modulo mod. mod must be positive.
+func (m *IntMatrix) WithMod(mod int64) *IntMatrix {
+	if mod <= 0 {
+		panic("bad modulus")
+	}
+	out := &IntMatrix{rows: m.rows, cols: m.cols, cells: make([]Int, len(m.cells)), mod: mod}
+	for i, v := range m.cells {
+		out.cells[i] = v.Mod(mod)
+	}
+	return out
+}
+
+func (m *IntMatrix) Rows() int { return m.rows }
+func (m *IntMatrix) Cols() int { return m.cols }
+
+// Mod returns the modulus of m, or 0 if it has none.
+func (m *IntMatrix) Mod() int64 { return m.mod }
+
+func (m *IntMatrix) checkCell(r, c int) {
+	if r < 0 || r >= m.rows || c < 0 || c >= m.cols {
+		panic(fmt.Sprintf("cell %d,%d out of range for %dx%d matrix", r, c, m.rows, m.cols))
+	}
+}
+
+func (m *IntMatrix) At(r, c int) Int {
+	m.checkCell(r, c)
+	return m.cells[r*m.cols+c]
+}
+
+func (m *IntMatrix) Set(r, c int, v Int) {
+	m.checkCell(r, c)
+	if m.mod != 0 {
+		v = v.Mod(m.mod)
+	}
+	m.cells[r*m.cols+c] = v
+}
+
+// AddAt adds v to the entry at r,c.
+func (m *IntMatrix) AddAt(r, c int, v int64) {
+	m.Set(r, c, m.At(r, c).Add(NewInt(v)))
+}
+
+// resultMod returns the modulus for the product of m and o, which must agree
+// if both have one.
+func (m *IntMatrix) resultMod(o *IntMatrix) int64 {
+	if m.mod != 0 && o.mod != 0 && m.mod != o.mod {
+		panic(fmt.Sprintf("modulus mismatch %d vs %d", m.mod, o.mod))
+	}
+	return max(m.mod, o.mod)
+}
+
+// mulAdd returns acc + a*b, reduced modulo mod if it's nonzero.
+func mulAdd(acc, a, b Int, mod int64) Int {
+	if mod == 0 {
+		return acc.Add(a.Mul(b))
+	}
+
+	// Both a and b are already reduced, so they fit in int64s.
+	av, _ := a.Mod(mod).Int64()
+	bv, _ := b.Mod(mod).Int64()
+	accv, _ := acc.Int64()
+	p := MulMod(av, bv, mod)
+	if accv >= mod-p {
+		return NewInt(accv - (mod - p))
+	}
+	return NewInt(accv + p)
+}
+
+// Mul returns the matrix product m·o.
+func (m *IntMatrix) Mul(o *IntMatrix) *IntMatrix {
+	if m.cols != o.rows {
+		panic(fmt.Sprintf("can't multiply %dx%d by %dx%d", m.rows, m.cols, o.rows, o.cols))
+	}
+
+	out := NewIntMatrix(m.rows, o.cols)
+	out.mod = m.resultMod(o)
+	for r := 0; r < m.rows; r++ {
+		outRow := out.cells[r*o.cols : (r+1)*o.cols]
+		for k := 0; k < m.cols; k++ {
+			a := m.cells[r*m.cols+k]
+			if a.IsZero() {
+				continue
+			}
+			for c, b := range o.cells[k*o.cols : (k+1)*o.cols] {
+				if !b.IsZero() {
+					outRow[c] = mulAdd(outRow[c], a, b, out.mod)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// MulVector returns m·v, treating v as a column vector.
+func (m *IntMatrix) MulVector(v []Int) []Int {
+	if len(v) != m.cols {
+		panic(fmt.Sprintf("vector has %d entries, want %d", len(v), m.cols))
+	}
+
+	out := make([]Int, m.rows)
+	for r := range out {
+		for c, a := range m.cells[r*m.cols : (r+1)*m.cols] {
+			if !a.IsZero() && !v[c].IsZero() {
+				out[r] = mulAdd(out[r], a, v[c], m.mod)
+			}
+		}
+	}
+	return out
+}
+
+// Pow returns m^n using exponentiation by squaring. m must be square and n
+// non-negative.
+func (m *IntMatrix) Pow(n int64) *IntMatrix {
+	if m.rows != m.cols {
+		panic("matrix not square")
+	}
+	if n < 0 {
+		panic("negative exponent")
+	}
+
+	out := IdentityIntMatrix(m.rows)
+	if m.mod != 0 {
+		out = out.WithMod(m.mod)
+	}
+	for base := m; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			out = out.Mul(base)
+		}
+		if n > 1 {
+			base = base.Mul(base)
+		}
+	}
+	return out
+}
+
+func (m *IntMatrix) Equal(o *IntMatrix) bool {
+	if m.rows != o.rows || m.cols != o.cols || m.mod != o.mod {
+		return false
+	}
+	for i, v := range m.cells {
+		if !v.Equal(o.cells[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *IntMatrix) String() string {
+	rows := make([]string, m.rows)
+	for r := range rows {
+		vals := make([]string, m.cols)
+		for c := range vals {
+			vals[c] = m.cells[r*m.cols+c].String()
+		}
+		rows[r] = "[" + strings.Join(vals, " ") + "]"
+	}
+	return "[" + strings.Join(rows, " ") + "]"
+}
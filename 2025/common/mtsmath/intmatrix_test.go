@@ -0,0 +1,68 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtsmath
+
+import "testing"
+
+func TestIntMatrixFibonacci(t *testing.T) {
+	fib := IntMatrixFrom([][]int64{{1, 1}, {1, 0}})
+
+	type TestCase struct {
+		n    int64
+		mod  int64
+		want string
+	}
+
+	testCases := []TestCase{
+		TestCase{n: 0, want: "0"},
+		TestCase{n: 1, want: "1"},
+		TestCase{n: 10, want: "55"},
+		TestCase{n: 100, want: "354224848179261915075"},
+		TestCase{n: 1000000000000000000, mod: 1000000007, want: "209783453"},
+		TestCase{n: 1000, mod: 1<<62 + 135, want: "1068440219976004600"},
+	}
+
+	for _, tc := range testCases {
+		m := fib
+		if tc.mod != 0 {
+			m = m.WithMod(tc.mod)
+		}
+		if got := m.Pow(tc.n).At(0, 1).String(); got != tc.want {
+			t.Errorf("F(%v) mod %v = %v, want %v", tc.n, tc.mod, got, tc.want)
+		}
+	}
+}
+
+func TestIntMatrix(t *testing.T) {
+	a := IntMatrixFrom([][]int64{{1, 2, 3}, {4, 5, 6}})
+	b := IntMatrixFrom([][]int64{{7, 8}, {9, 10}, {11, 12}})
+
+	if got, want := a.Mul(b), IntMatrixFrom([][]int64{{58, 64}, {139, 154}}); !got.Equal(want) {
+		t.Errorf("a·b = %v, want %v", got, want)
+	}
+	if got, want := a.WithMod(7).Mul(b), IntMatrixFrom([][]int64{{2, 1}, {6, 0}}).WithMod(7); !got.Equal(want) {
+		t.Errorf("a·b mod 7 = %v, want %v", got, want)
+	}
+
+	v := []Int{NewInt(1), NewInt(0), NewInt(-1)}
+	got := a.MulVector(v)
+	if len(got) != 2 || got[0].String() != "-2" || got[1].String() != "-2" {
+		t.Errorf("a·%v = %v, want [-2 -2]", v, got)
+	}
+
+	if got := b.Mul(IdentityIntMatrix(2)); !got.Equal(b) {
+		t.Errorf("b·I = %v, want %v", got, b)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtsmath
+
+type SolutionStatus int
+
+const (
+	UniqueSolution SolutionStatus = iota
+	NoSolution
+	InfiniteSolutions
+)
+
+func (s SolutionStatus) String() string {
+	switch s {
+	case UniqueSolution:
+		return "unique"
+	case NoSolution:
+		return "none"
+	case InfiniteSolutions:
+		return "infinite"
+	default:
+		panic("bad status")
+	}
+}
+
+// RatMatrix converts a matrix of integers into a matrix of Rats.
+func RatMatrix(in [][]int64) [][]Rat {
+	out := make([][]Rat, len(in))
+	for i, row := range in {
+		out[i] = RatVector(row)
+	}
+	return out
+}
+
+// RatVector converts a slice of integers into a slice of Rats.
+func RatVector(in []int64) []Rat {
+	out := make([]Rat, len(in))
+	for i, v := range in {
+		out[i] = RatFromInt(v)
+	}
+	return out
+}
+
+// IntVector returns the elements of in as integers if every one of them is an
+// integer that fits in an int64. Applied to a unique solution from
+// SolveLinear, it says whether the system has an integer solution. For
+// InfiniteSolutions it only checks the one solution returned: others may be
+// integers even if that one isn't (use the ilp package to search for them).
+func IntVector(in []Rat) ([]int64, bool) {
+	out := make([]int64, len(in))
+	for i, v := range in {
+		var ok bool
+		if out[i], ok = v.Int64(); !ok {
+			return nil, false
+		}
+	}
+	return out, true
+}
+
+func cloneMatrix(m [][]Rat) [][]Rat {
+	out := make([][]Rat, len(m))
+	for i, row := range m {
+		out[i] = append([]Rat(nil), row...)
+	}
+	return out
+}
+
+// rowReduce puts m into reduced row echelon form in place, considering only
+// the first numCols columns when choosing pivots. It returns the pivot column
+// for each of the leading rows.
+func rowReduce(m [][]Rat, numCols int) []int {
+	pivots := []int{}
+	row := 0
+	for col := 0; col < numCols && row < len(m); col++ {
+		pivot := -1
+		for r := row; r < len(m); r++ {
+			if !m[r][col].IsZero() {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		m[row], m[pivot] = m[pivot], m[row]
+
+		inv := m[row][col].Inv()
+		for c := col; c < len(m[row]); c++ {
+			m[row][c] = m[row][c].Mul(inv)
+		}
+
+		for r := range m {
+			if r == row || m[r][col].IsZero() {
+				continue
+			}
+			factor := m[r][col]
+			for c := col; c < len(m[r]); c++ {
+				m[r][c] = m[r][c].Sub(factor.Mul(m[row][c]))
+			}
+		}
+
+		pivots = append(pivots, col)
+		row++
+	}
+	return pivots
+}
+
+// SolveLinear solves a·x = b using Gaussian elimination, where a is an m×n
+// matrix and b has m elements. Neither a nor b is modified.
+//
+// If the system has infinitely many solutions, the returned x is one of them,
+// with every free variable set to zero, which may be fractional even when
+// integer solutions exist. If it has no solution, x is nil.
+func SolveLinear(a [][]Rat, b []Rat) ([]Rat, SolutionStatus) {
+	if len(a) != len(b) {
+		panic("row count mismatch")
+	}
+
+	numVars := 0
+	if len(a) > 0 {
+		numVars = len(a[0])
+	}
+
+	aug := make([][]Rat, len(a))
+	for i, row := range a {
+		if len(row) != numVars {
+			panic("ragged matrix")
+		}
+		aug[i] = append(append([]Rat(nil), row...), b[i])
+	}
+
+	pivots := rowReduce(aug, numVars)
+
+	// Any all-zero row with a nonzero constant is a contradiction.
+	for r := len(pivots); r < len(aug); r++ {
+		if !aug[r][numVars].IsZero() {
+			return nil, NoSolution
+		}
+	}
+
+	x := make([]Rat, numVars)
+	for r, col := range pivots {
+		x[col] = aug[r][numVars]
+	}
+
+	if len(pivots) < numVars {
+		return x, InfiniteSolutions
+	}
+	return x, UniqueSolution
+}
+
+// Determinant returns the determinant of the square matrix m.
+func Determinant(m [][]Rat) Rat {
+	n := len(m)
+	for _, row := range m {
+		if len(row) != n {
+			panic("not square")
+		}
+	}
+	m = cloneMatrix(m)
+
+	det := RatFromInt(1)
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for r := col; r < n; r++ {
+			if !m[r][col].IsZero() {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			return Rat{}
+		}
+		if pivot != col {
+			m[col], m[pivot] = m[pivot], m[col]
+			det = det.Neg()
+		}
+
+		det = det.Mul(m[col][col])
+		for r := col + 1; r < n; r++ {
+			if m[r][col].IsZero() {
+				continue
+			}
+			factor := m[r][col].Div(m[col][col])
+			for c := col; c < n; c++ {
+				m[r][c] = m[r][c].Sub(factor.Mul(m[col][c]))
+			}
+		}
+	}
+	return det
+}
+
+// Inverse returns the inverse of the square matrix m, or false if m is
+// singular.
+func Inverse(m [][]Rat) ([][]Rat, bool) {
+	n := len(m)
+	aug := make([][]Rat, n)
+	for i, row := range m {
+		if len(row) != n {
+			panic("not square")
+		}
+		aug[i] = make([]Rat, 2*n)
+		copy(aug[i], row)
+		aug[i][n+i] = RatFromInt(1)
+	}
+
+	if pivots := rowReduce(aug, n); len(pivots) != n {
+		return nil, false
+	}
+
+	out := make([][]Rat, n)
+	for i := range aug {
+		out[i] = aug[i][n:]
+	}
+	return out, true
+}
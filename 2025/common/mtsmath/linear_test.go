@@ -0,0 +1,157 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtsmath
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSolveLinear(t *testing.T) {
+	type TestCase struct {
+		name       string
+		a          [][]int64
+		b          []int64
+		wantStatus SolutionStatus
+		want       []string
+	}
+
+	testCases := []TestCase{
+		TestCase{
+			name:       "claw machine",
+			a:          [][]int64{{94, 22}, {34, 67}},
+			b:          []int64{8400, 5400},
+			wantStatus: UniqueSolution,
+			want:       []string{"80", "40"},
+		},
+		TestCase{
+			name:       "fractional",
+			a:          [][]int64{{2, 0}, {0, 3}},
+			b:          []int64{1, 1},
+			wantStatus: UniqueSolution,
+			want:       []string{"1/2", "1/3"},
+		},
+		TestCase{
+			name:       "needs pivoting",
+			a:          [][]int64{{0, 1}, {1, 0}},
+			b:          []int64{4, 5},
+			wantStatus: UniqueSolution,
+			want:       []string{"5", "4"},
+		},
+		TestCase{
+			name:       "overdetermined",
+			a:          [][]int64{{1, 0}, {0, 1}, {1, 1}},
+			b:          []int64{2, 3, 5},
+			wantStatus: UniqueSolution,
+			want:       []string{"2", "3"},
+		},
+		TestCase{
+			name:       "inconsistent",
+			a:          [][]int64{{1, 1}, {2, 2}},
+			b:          []int64{1, 3},
+			wantStatus: NoSolution,
+		},
+		TestCase{
+			name:       "dependent",
+			a:          [][]int64{{1, 1}, {2, 2}},
+			b:          []int64{4, 8},
+			wantStatus: InfiniteSolutions,
+			want:       []string{"4", "0"},
+		},
+		TestCase{
+			name: "large coordinates",
+			a: [][]int64{
+				{300000000000000, 1},
+				{-200000000000000, 1},
+			},
+			b:          []int64{900000000000000000, 400000000000000000},
+			wantStatus: UniqueSolution,
+			want:       []string{"1000", "600000000000000000"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			x, status := SolveLinear(RatMatrix(tc.a), RatVector(tc.b))
+			if status != tc.wantStatus {
+				t.Fatalf("SolveLinear status = %v, want %v",
+					status, tc.wantStatus)
+			}
+
+			var got []string
+			for _, v := range x {
+				got = append(got, v.String())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("SolveLinear = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIntVector(t *testing.T) {
+	if got, ok := IntVector(RatVector([]int64{1, 2})); !ok || !reflect.DeepEqual(got, []int64{1, 2}) {
+		t.Errorf("IntVector([1 2]) = %v, %v, want [1 2], true", got, ok)
+	}
+	if _, ok := IntVector([]Rat{NewRat(1, 2)}); ok {
+		t.Errorf("IntVector([1/2]) = _, true, want false")
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	type TestCase struct {
+		m    [][]int64
+		want string
+	}
+
+	testCases := []TestCase{
+		TestCase{[][]int64{{3}}, "3"},
+		TestCase{[][]int64{{1, 2}, {3, 4}}, "-2"},
+		TestCase{[][]int64{{0, 1}, {1, 0}}, "-1"},
+		TestCase{[][]int64{{1, 2}, {2, 4}}, "0"},
+		TestCase{[][]int64{{2, 0, 1}, {1, 3, 2}, {1, 1, 2}}, "6"},
+	}
+
+	for _, tc := range testCases {
+		if got := Determinant(RatMatrix(tc.m)).String(); got != tc.want {
+			t.Errorf("Determinant(%v) = %v, want %v", tc.m, got, tc.want)
+		}
+	}
+}
+
+func TestInverse(t *testing.T) {
+	inv, ok := Inverse(RatMatrix([][]int64{{4, 7}, {2, 6}}))
+	if !ok {
+		t.Fatalf("Inverse failed")
+	}
+
+	got := [][]string{}
+	for _, row := range inv {
+		strs := []string{}
+		for _, v := range row {
+			strs = append(strs, v.String())
+		}
+		got = append(got, strs)
+	}
+
+	want := [][]string{{"3/5", "-7/10"}, {"-1/5", "2/5"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Inverse = %v, want %v", got, want)
+	}
+
+	if _, ok := Inverse(RatMatrix([][]int64{{1, 2}, {2, 4}})); ok {
+		t.Errorf("Inverse of singular matrix = _, true, want false")
+	}
+}
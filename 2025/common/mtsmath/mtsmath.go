@@ -21,7 +21,12 @@ import (
 	"golang.org/x/exp/constraints"
 )
 
-func Abs[V constraints.Integer | constraints.Float](val V) V {
+// Number is any built-in integer or floating-point type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+func Abs[V Number](val V) V {
 	if val > 0 {
 		return val
 	} else {
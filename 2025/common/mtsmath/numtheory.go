@@ -0,0 +1,254 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtsmath
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// Mod returns a mod m in the range [0, m), regardless of the sign of a. m must
+// be positive.
+func Mod(a, m int64) int64 {
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// AddMod returns a+b mod m without overflowing, in the range [0, m). m must be
+// positive.
+func AddMod(a, b, m int64) int64 {
+	a, b = Mod(a, m), Mod(b, m)
+	if a >= m-b {
+		return a - (m - b)
+	}
+	return a + b
+}
+
+// MulMod returns a*b mod m without overflowing, in the range [0, m). m must be
+// positive.
+func MulMod(a, b, m int64) int64 {
+	ua, ub := uint64(Mod(a, m)), uint64(Mod(b, m))
+	hi, lo := bits.Mul64(ua, ub)
+	return int64(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// PowMod returns base^exp mod m using exponentiation by squaring. exp must be
+// non-negative and m positive.
+func PowMod(base, exp, m int64) int64 {
+	if exp < 0 {
+		panic("negative exponent")
+	}
+
+	result := Mod(1, m)
+	base = Mod(base, m)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = MulMod(result, base, m)
+		}
+		base = MulMod(base, base, m)
+	}
+	return result
+}
+
+// ExtendedGCD returns g = gcd(a, b) along with x and y such that a*x + b*y =
+// g.
+func ExtendedGCD(a, b int64) (g, x, y int64) {
+	oldR, r := a, b
+	oldS, s := int64(1), int64(0)
+	oldT, t := int64(0), int64(1)
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldS, s = s, oldS-q*s
+		oldT, t = t, oldT-q*t
+	}
+	if oldR < 0 {
+		oldR, oldS, oldT = -oldR, -oldS, -oldT
+	}
+	return oldR, oldS, oldT
+}
+
+// ModInverse returns x such that a*x ≡ 1 (mod m), or false if a and m aren't
+// coprime.
+func ModInverse(a, m int64) (int64, bool) {
+	g, x, _ := ExtendedGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+	return Mod(x, m), true
+}
+
+// CRT solves the system x ≡ residues[i] (mod moduli[i]) using the Chinese
+// Remainder Theorem. The moduli need not be pairwise coprime. It returns the
+// smallest non-negative solution x and the modulus (the LCM of moduli) under
+// which it is unique, or false if the system is inconsistent or the combined
+// modulus doesn't fit in an int64.
+func CRT(residues, moduli []int64) (x, m int64, ok bool) {
+	if len(residues) != len(moduli) {
+		panic("length mismatch")
+	}
+
+	x, m = 0, 1
+	for i, mi := range moduli {
+		ri := Mod(residues[i], mi)
+
+		// Find k with x + m*k ≡ ri (mod mi).
+		g := gcd(m, mi)
+		diff := ri - Mod(x, mi)
+		if diff%g != 0 {
+			return 0, 0, false
+		}
+
+		lcm, ok := MulOK(m/g, mi)
+		if !ok {
+			return 0, 0, false
+		}
+
+		step := mi / g
+		inv, _ := ModInverse(m/g, step) // coprime by construction
+		k := MulMod(diff/g, inv, step)
+
+		// k < mi/g, so x + m*k < lcm and can't overflow.
+		x += m * k
+		m = lcm
+	}
+	return x, m, true
+}
+
+// DiscreteLog returns the smallest non-negative x such that g^x ≡ h (mod m)
+// using baby-step giant-step, or false if there isn't one. g must be coprime
+// to m. Time and memory are both O(sqrt(m)).
+func DiscreteLog(g, h, m int64) (int64, bool) {
+	h = Mod(h, m)
+	n := int64(math.Ceil(math.Sqrt(float64(m))))
+
+	baby := make(map[int64]int64, n)
+	cur := Mod(1, m)
+	for j := int64(0); j < n; j++ {
+		if _, found := baby[cur]; !found {
+			baby[cur] = j
+		}
+		cur = MulMod(cur, g, m)
+	}
+
+	gInv, ok := ModInverse(g, m)
+	if !ok {
+		panic("g not coprime to m")
+	}
+	giant := PowMod(gInv, n, m)
+
+	gamma := h
+	for i := int64(0); i <= n; i++ {
+		if j, found := baby[gamma]; found {
+			return i*n + j, true
+		}
+		gamma = MulMod(gamma, giant, m)
+	}
+	return 0, false
+}
+
+// Primes returns every prime less than or equal to n, in ascending order,
+// using the Sieve of Eratosthenes.
+func Primes(n int) []int64 {
+	if n < 2 {
+		return nil
+	}
+
+	composite := make([]bool, n+1)
+	out := []int64{}
+	for i := 2; i <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		out = append(out, int64(i))
+		for j := i * i; j <= n; j += i {
+			composite[j] = true
+		}
+	}
+	return out
+}
+
+type PrimeFactor struct {
+	Prime, Exp int64
+}
+
+// Factorize returns the prime factorization of n (which must be positive) in
+// ascending order of prime, using trial division.
+func Factorize(n int64) []PrimeFactor {
+	if n <= 0 {
+		panic("bad n")
+	}
+
+	out := []PrimeFactor{}
+	for p := int64(2); p <= n/p; p++ {
+		if n%p != 0 {
+			continue
+		}
+		f := PrimeFactor{Prime: p}
+		for n%p == 0 {
+			n /= p
+			f.Exp++
+		}
+		out = append(out, f)
+	}
+	if n > 1 {
+		out = append(out, PrimeFactor{Prime: n, Exp: 1})
+	}
+	return out
+}
+
+// Divisors returns every positive divisor of n (including 1 and n) in
+// ascending order.
+func Divisors(n int64) []int64 {
+	out := []int64{1}
+	for _, f := range Factorize(n) {
+		cur := len(out)
+		pk := int64(1)
+		for e := int64(0); e < f.Exp; e++ {
+			pk *= f.Prime
+			for _, d := range out[:cur] {
+				out = append(out, d*pk)
+			}
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// DivisorSum returns the sum of every positive divisor of n (including 1 and
+// n). The sum can be several times n, so it panics if it doesn't fit in an
+// int64.
+func DivisorSum(n int64) int64 {
+	c := overflowChecker{}
+	sum := int64(1)
+	for _, f := range Factorize(n) {
+		// 1 + p + p^2 + ... + p^e
+		term, pk := int64(1), int64(1)
+		for e := int64(0); e < f.Exp; e++ {
+			pk = c.mul(pk, f.Prime)
+			term = c.add(term, pk)
+		}
+		sum = c.mul(sum, term)
+	}
+	if c.overflow {
+		panic(fmt.Sprintf("divisor sum of %d overflows", n))
+	}
+	return sum
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtsmath
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAddMod(t *testing.T) {
+	if got, want := AddMod(-3, 5, 7), int64(2); got != want {
+		t.Errorf("AddMod(-3,5,7) = %v, want %v", got, want)
+	}
+
+	// The sum overflows int64.
+	const m = math.MaxInt64 - 24
+	if got, want := AddMod(m-1, m-2, m), int64(m-3); got != want {
+		t.Errorf("AddMod(m-1,m-2,m) = %v, want %v", got, want)
+	}
+	if got, want := AddMod(m-1, 1, m), int64(0); got != want {
+		t.Errorf("AddMod(m-1,1,m) = %v, want %v", got, want)
+	}
+}
+
+func TestMulModPowMod(t *testing.T) {
+	if got, want := MulMod(-3, 5, 7), int64(6); got != want {
+		t.Errorf("MulMod(-3,5,7) = %v, want %v", got, want)
+	}
+
+	// Both operands near 2^62; the product overflows int64.
+	const m = math.MaxInt64 - 24 // prime
+	if got, want := MulMod(m-1, m-1, m), int64(1); got != want {
+		t.Errorf("MulMod(m-1,m-1,m) = %v, want %v", got, want)
+	}
+
+	if got, want := PowMod(2, 10, 1000), int64(24); got != want {
+		t.Errorf("PowMod(2,10,1000) = %v, want %v", got, want)
+	}
+	if got, want := PowMod(5, 0, 1), int64(0); got != want {
+		t.Errorf("PowMod(5,0,1) = %v, want %v", got, want)
+	}
+	// Fermat's little theorem.
+	if got, want := PowMod(3, m-1, m), int64(1); got != want {
+		t.Errorf("PowMod(3,m-1,m) = %v, want %v", got, want)
+	}
+}
+
+func TestExtendedGCD(t *testing.T) {
+	for _, tc := range [][2]int64{{240, 46}, {46, 240}, {-240, 46}, {17, 5}, {0, 5}} {
+		g, x, y := ExtendedGCD(tc[0], tc[1])
+		if want := gcd(Abs(tc[0]), Abs(tc[1])); g != want {
+			t.Errorf("ExtendedGCD(%v) g = %v, want %v", tc, g, want)
+		}
+		if tc[0]*x+tc[1]*y != g {
+			t.Errorf("ExtendedGCD(%v) = %v, %v, %v; bad coefficients",
+				tc, g, x, y)
+		}
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	if got, ok := ModInverse(3, 11); !ok || got != 4 {
+		t.Errorf("ModInverse(3,11) = %v, %v, want 4, true", got, ok)
+	}
+	if got, ok := ModInverse(-3, 11); !ok || got != 7 {
+		t.Errorf("ModInverse(-3,11) = %v, %v, want 7, true", got, ok)
+	}
+	if _, ok := ModInverse(4, 8); ok {
+		t.Errorf("ModInverse(4,8) = _, true, want false")
+	}
+}
+
+func TestCRT(t *testing.T) {
+	type TestCase struct {
+		residues, moduli []int64
+		wantX, wantM     int64
+		wantOK           bool
+	}
+
+	testCases := []TestCase{
+		TestCase{[]int64{2, 3, 2}, []int64{3, 5, 7}, 23, 105, true},
+		// 2020/13 sample: buses 7,13,x,x,59,x,31,19
+		TestCase{
+			[]int64{0, -1, -4, -6, -7},
+			[]int64{7, 13, 59, 31, 19},
+			1068781, 3162341, true,
+		},
+		TestCase{[]int64{3, 5}, []int64{4, 6}, 11, 12, true},
+		TestCase{[]int64{1, 2}, []int64{4, 6}, 0, 0, false},
+		TestCase{
+			[]int64{1, 2},
+			[]int64{1000000007, 998244353},
+			993328913953302350, 998244359987710471, true,
+		},
+		TestCase{
+			[]int64{0, 0},
+			[]int64{1 << 40, (1 << 40) - 1},
+			0, 0, false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.residues, tc.moduli), func(t *testing.T) {
+			x, m, ok := CRT(tc.residues, tc.moduli)
+			if ok != tc.wantOK || x != tc.wantX || m != tc.wantM {
+				t.Errorf("CRT = %v, %v, %v, want %v, %v, %v",
+					x, m, ok, tc.wantX, tc.wantM, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestDiscreteLog(t *testing.T) {
+	// 2020/25 sample
+	if got, ok := DiscreteLog(7, 5764801, 20201227); !ok || got != 8 {
+		t.Errorf("DiscreteLog(card) = %v, %v, want 8, true", got, ok)
+	}
+	if got, ok := DiscreteLog(7, 17807724, 20201227); !ok || got != 11 {
+		t.Errorf("DiscreteLog(door) = %v, %v, want 11, true", got, ok)
+	}
+	if got, ok := DiscreteLog(3, 1, 7); !ok || got != 0 {
+		t.Errorf("DiscreteLog(3,1,7) = %v, %v, want 0, true", got, ok)
+	}
+	// 2 generates {1,2,4} mod 7, so 3 is unreachable.
+	if _, ok := DiscreteLog(2, 3, 7); ok {
+		t.Errorf("DiscreteLog(2,3,7) = _, true, want false")
+	}
+}
+
+func TestPrimes(t *testing.T) {
+	if got := Primes(1); got != nil {
+		t.Errorf("Primes(1) = %v, want nil", got)
+	}
+	want := []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
+	if got := Primes(30); !reflect.DeepEqual(got, want) {
+		t.Errorf("Primes(30) = %v, want %v", got, want)
+	}
+}
+
+func TestFactorize(t *testing.T) {
+	type TestCase struct {
+		n    int64
+		want []PrimeFactor
+	}
+
+	testCases := []TestCase{
+		TestCase{1, []PrimeFactor{}},
+		TestCase{2, []PrimeFactor{{2, 1}}},
+		TestCase{360, []PrimeFactor{{2, 3}, {3, 2}, {5, 1}}},
+		TestCase{10551367, []PrimeFactor{{2801, 1}, {3767, 1}}},
+	}
+
+	for _, tc := range testCases {
+		if got := Factorize(tc.n); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Factorize(%v) = %v, want %v", tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestDivisors(t *testing.T) {
+	want := []int64{1, 2, 3, 4, 6, 12}
+	if got := Divisors(12); !reflect.DeepEqual(got, want) {
+		t.Errorf("Divisors(12) = %v, want %v", got, want)
+	}
+	if got, want := Divisors(1), []int64{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Divisors(1) = %v, want %v", got, want)
+	}
+
+	for n := int64(1); n < 200; n++ {
+		sum := int64(0)
+		for _, d := range Divisors(n) {
+			sum += d
+		}
+		if got := DivisorSum(n); got != sum {
+			t.Errorf("DivisorSum(%v) = %v, want %v", n, got, sum)
+		}
+	}
+
+	// 1 + 2 + ... + 2^62 only just fits.
+	if got, want := DivisorSum(1<<62), int64(math.MaxInt64); got != want {
+		t.Errorf("DivisorSum(2^62) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtsmath
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Rat is an exact rational number. Values are kept as a reduced pair of
+// int64s while they fit, and are transparently promoted to a big.Rat when an
+// operation would overflow (and demoted again when the result fits). The zero
+// value is 0.
+//
+// Rats are immutable. Because big-backed values hold a pointer, use Equal
+// rather than == to compare them.
+type Rat struct {
+	num, den int64 // den == 0 is treated as 1 so the zero value works
+	b        *big.Rat
+}
+
+// overflowChecker performs int64 arithmetic, remembering whether any
+// operation overflowed. math.MinInt64 is treated as an overflow so that
+// negation and Abs are always safe on the results.
+type overflowChecker struct {
+	overflow bool
+}
+
+func (c *overflowChecker) mul(a, b int64) int64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	r := a * b
+	if r/b != a || r == math.MinInt64 || a == math.MinInt64 || b == math.MinInt64 {
+		c.overflow = true
+	}
+	return r
+}
+
+func (c *overflowChecker) add(a, b int64) int64 {
+	r := a + b
+	if (a > 0 && b > 0 && r < 0) || (a < 0 && b < 0 && r >= 0) || r == math.MinInt64 {
+		c.overflow = true
+	}
+	return r
+}
+
+// MulOK returns a*b and whether the product fit in an int64.
+func MulOK(a, b int64) (int64, bool) {
+	c := overflowChecker{}
+	r := c.mul(a, b)
+	return r, !c.overflow
+}
+
+// AddOK returns a+b and whether the sum fit in an int64.
+func AddOK(a, b int64) (int64, bool) {
+	c := overflowChecker{}
+	r := c.add(a, b)
+	return r, !c.overflow
+}
+
+// NewRat returns num/den. It panics if den is zero.
+func NewRat(num, den int64) Rat {
+	if den == 0 {
+		panic("zero denominator")
+	}
+	if num == math.MinInt64 || den == math.MinInt64 {
+		return ratFromBig(big.NewRat(num, den))
+	}
+
+	if den < 0 {
+		num, den = -num, -den
+	}
+	if g := gcd(Abs(num), den); g > 1 {
+		num, den = num/g, den/g
+	}
+	return Rat{num: num, den: den}
+}
+
+// RatFromInt returns v/1.
+func RatFromInt(v int64) Rat {
+	return NewRat(v, 1)
+}
+
+// RatFromBig returns a Rat with the value of v. v is not retained.
+func RatFromBig(v *big.Rat) Rat {
+	return ratFromBig(new(big.Rat).Set(v))
+}
+
+// ratFromBig is RatFromBig without the copy, for values we own.
+func ratFromBig(v *big.Rat) Rat {
+	n, d := v.Num(), v.Denom()
+	if n.IsInt64() && d.IsInt64() {
+		ni, di := n.Int64(), d.Int64()
+		if ni != math.MinInt64 && di != math.MinInt64 {
+			return Rat{num: ni, den: di}
+		}
+	}
+	return Rat{b: v}
+}
+
+func (r Rat) d() int64 {
+	if r.den == 0 {
+		return 1
+	}
+	return r.den
+}
+
+func (r Rat) toBig() *big.Rat {
+	if r.b != nil {
+		return r.b
+	}
+	return big.NewRat(r.num, r.d())
+}
+
+func (r Rat) Add(o Rat) Rat {
+	if r.b == nil && o.b == nil {
+		c := overflowChecker{}
+		n := c.add(c.mul(r.num, o.d()), c.mul(o.num, r.d()))
+		d := c.mul(r.d(), o.d())
+		if !c.overflow {
+			return NewRat(n, d)
+		}
+	}
+	return ratFromBig(new(big.Rat).Add(r.toBig(), o.toBig()))
+}
+
+func (r Rat) Sub(o Rat) Rat {
+	return r.Add(o.Neg())
+}
+
+func (r Rat) Mul(o Rat) Rat {
+	if r.b == nil && o.b == nil {
+		// Cross-reduce first to keep intermediates small.
+		rn, rd, on, od := r.num, r.d(), o.num, o.d()
+		if g := gcd(Abs(rn), od); g > 1 {
+			rn, od = rn/g, od/g
+		}
+		if g := gcd(Abs(on), rd); g > 1 {
+			on, rd = on/g, rd/g
+		}
+
+		c := overflowChecker{}
+		n := c.mul(rn, on)
+		d := c.mul(rd, od)
+		if !c.overflow {
+			return NewRat(n, d)
+		}
+	}
+	return ratFromBig(new(big.Rat).Mul(r.toBig(), o.toBig()))
+}
+
+// Div returns r/o. It panics if o is zero.
+func (r Rat) Div(o Rat) Rat {
+	return r.Mul(o.Inv())
+}
+
+func (r Rat) Neg() Rat {
+	if r.b != nil {
+		return ratFromBig(new(big.Rat).Neg(r.b))
+	}
+	return Rat{num: -r.num, den: r.den}
+}
+
+// Inv returns 1/r. It panics if r is zero.
+func (r Rat) Inv() Rat {
+	if r.IsZero() {
+		panic("inverse of zero")
+	}
+	if r.b != nil {
+		return ratFromBig(new(big.Rat).Inv(r.b))
+	}
+	return NewRat(r.d(), r.num)
+}
+
+func (r Rat) Abs() Rat {
+	if r.Sign() < 0 {
+		return r.Neg()
+	}
+	return r
+}
+
+// Cmp returns -1, 0, or 1 depending on whether r is less than, equal to, or
+// greater than o.
+func (r Rat) Cmp(o Rat) int {
+	if r.b == nil && o.b == nil {
+		c := overflowChecker{}
+		a := c.mul(r.num, o.d())
+		b := c.mul(o.num, r.d())
+		if !c.overflow {
+			switch {
+			case a < b:
+				return -1
+			case a > b:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	return r.toBig().Cmp(o.toBig())
+}
+
+func (r Rat) Equal(o Rat) bool {
+	return r.Cmp(o) == 0
+}
+
+func (r Rat) Sign() int {
+	if r.b != nil {
+		return r.b.Sign()
+	}
+	switch {
+	case r.num < 0:
+		return -1
+	case r.num > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (r Rat) IsZero() bool {
+	return r.Sign() == 0
+}
+
+func (r Rat) IsInt() bool {
+	if r.b != nil {
+		return r.b.IsInt()
+	}
+	return r.d() == 1
+}
+
+// Int64 returns the value of r if it is an integer that fits in an int64.
+func (r Rat) Int64() (int64, bool) {
+	if r.b != nil || r.d() != 1 {
+		return 0, false
+	}
+	return r.num, true
+}
+
+// Floor returns the largest integer less than or equal to r.
+func (r Rat) Floor() Rat {
+	if r.b != nil {
+		// big.Int.Div is Euclidean, which floors for positive divisors.
+		return ratFromBig(new(big.Rat).SetInt(
+			new(big.Int).Div(r.b.Num(), r.b.Denom())))
+	}
+	q := r.num / r.d()
+	if r.num%r.d() != 0 && r.num < 0 {
+		q--
+	}
+	return RatFromInt(q)
+}
+
+// Ceil returns the smallest integer greater than or equal to r.
+func (r Rat) Ceil() Rat {
+	return r.Neg().Floor().Neg()
+}
+
+// Big returns the value of r as a newly-allocated big.Rat.
+func (r Rat) Big() *big.Rat {
+	return new(big.Rat).Set(r.toBig())
+}
+
+// Num returns the numerator of r in lowest terms. The sign of r is carried by
+// the numerator.
+func (r Rat) Num() *big.Int {
+	if r.b != nil {
+		return new(big.Int).Set(r.b.Num())
+	}
+	return big.NewInt(r.num)
+}
+
+// Den returns the (always positive) denominator of r in lowest terms.
+func (r Rat) Den() *big.Int {
+	if r.b != nil {
+		return new(big.Int).Set(r.b.Denom())
+	}
+	return big.NewInt(r.d())
+}
+
+func (r Rat) String() string {
+	if r.b != nil {
+		return r.b.RatString()
+	}
+	if r.d() == 1 {
+		return fmt.Sprint(r.num)
+	}
+	return fmt.Sprintf("%d/%d", r.num, r.d())
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtsmath
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestRatBasics(t *testing.T) {
+	type TestCase struct {
+		name string
+		got  Rat
+		want string
+	}
+
+	half := NewRat(1, 2)
+	third := NewRat(1, 3)
+
+	testCases := []TestCase{
+		TestCase{"zero value", Rat{}, "0"},
+		TestCase{"reduce", NewRat(6, -4), "-3/2"},
+		TestCase{"add", half.Add(third), "5/6"},
+		TestCase{"sub", half.Sub(third), "1/6"},
+		TestCase{"mul", half.Mul(third), "1/6"},
+		TestCase{"div", half.Div(third), "3/2"},
+		TestCase{"neg", half.Neg(), "-1/2"},
+		TestCase{"inv", NewRat(-2, 3).Inv(), "-3/2"},
+		TestCase{"abs", NewRat(-2, 3).Abs(), "2/3"},
+		TestCase{"zero plus", Rat{}.Add(half), "1/2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.got.String(); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRatCmp(t *testing.T) {
+	if got := NewRat(1, 3).Cmp(NewRat(1, 2)); got != -1 {
+		t.Errorf("1/3 cmp 1/2 = %v, want -1", got)
+	}
+	if got := NewRat(2, 4).Cmp(NewRat(1, 2)); got != 0 {
+		t.Errorf("2/4 cmp 1/2 = %v, want 0", got)
+	}
+	if !NewRat(3, 3).IsInt() || NewRat(3, 2).IsInt() {
+		t.Errorf("IsInt mismatch")
+	}
+	if v, ok := NewRat(12, 4).Int64(); !ok || v != 3 {
+		t.Errorf("Int64(12/4) = %v, %v, want 3, true", v, ok)
+	}
+	if _, ok := NewRat(1, 4).Int64(); ok {
+		t.Errorf("Int64(1/4) = _, true, want false")
+	}
+}
+
+func TestRatOverflow(t *testing.T) {
+	big1 := RatFromInt(1e18)
+	sq := big1.Mul(big1)
+	if sq.b == nil {
+		t.Fatalf("1e18*1e18 not promoted to big")
+	}
+
+	want, _ := new(big.Rat).SetString("1000000000000000000000000000000000000")
+	if sq.Big().Cmp(want) != 0 {
+		t.Errorf("1e18*1e18 = %v, want %v", sq, want)
+	}
+
+	back := sq.Div(big1)
+	if back.b != nil {
+		t.Errorf("1e36/1e18 not demoted")
+	}
+	if v, ok := back.Int64(); !ok || v != 1e18 {
+		t.Errorf("1e36/1e18 = %v, %v, want 1e18, true", v, ok)
+	}
+
+	if got := RatFromInt(math.MaxInt64).Add(RatFromInt(1)).Sub(RatFromInt(1)); !got.Equal(RatFromInt(math.MaxInt64)) {
+		t.Errorf("MaxInt64+1-1 = %v", got)
+	}
+
+	if got := sq.Cmp(big1); got != 1 {
+		t.Errorf("1e36 cmp 1e18 = %v, want 1", got)
+	}
+
+	min := NewRat(math.MinInt64, 1)
+	if got := min.Neg().Neg(); !got.Equal(min) {
+		t.Errorf("--MinInt64 = %v, want %v", got, min)
+	}
+}
+
+func TestRatFloorCeil(t *testing.T) {
+	type TestCase struct {
+		in          Rat
+		floor, ceil string
+	}
+
+	huge := RatFromInt(1e18).Mul(RatFromInt(1e18))
+
+	testCases := []TestCase{
+		TestCase{NewRat(7, 2), "3", "4"},
+		TestCase{NewRat(-7, 2), "-4", "-3"},
+		TestCase{NewRat(4, 2), "2", "2"},
+		TestCase{NewRat(-4, 2), "-2", "-2"},
+		TestCase{Rat{}, "0", "0"},
+		TestCase{huge.Add(NewRat(1, 2)), huge.String(), huge.Add(RatFromInt(1)).String()},
+		TestCase{huge.Neg().Sub(NewRat(1, 2)), huge.Neg().Sub(RatFromInt(1)).String(), huge.Neg().String()},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.in.Floor().String(); got != tc.floor {
+			t.Errorf("Floor(%v) = %v, want %v", tc.in, got, tc.floor)
+		}
+		if got := tc.in.Ceil().String(); got != tc.ceil {
+			t.Errorf("Ceil(%v) = %v, want %v", tc.in, got, tc.ceil)
+		}
+	}
+}
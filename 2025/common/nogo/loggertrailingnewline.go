@@ -1,7 +1,6 @@
 package loggertrailingnewline
 
 import (
-	"fmt"
 	"go/ast"
 	"go/token"
 	"slices"
@@ -60,7 +59,7 @@ func checkFile(pass *analysis.Pass, f *ast.File) {
 
 		if strings.HasSuffix(msg.Value, "\\n\"") {
 			pass.Reportf(call.Pos(),
-				fmt.Sprintf("logger.%v format with trailing newline", ident.Name))
+				"logger.%v format with trailing newline", ident.Name)
 		}
 
 		return true
@@ -0,0 +1,175 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parallel spreads embarrassingly parallel work (brute-force scans,
+// expanding a search frontier) across goroutines while keeping results in a
+// deterministic order.
+package parallel
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Pool holds the settings shared by the functions in this package. A nil
+// *Pool uses the defaults.
+type Pool struct {
+	workers   int
+	chunkSize int
+}
+
+// New returns a pool with one worker per CPU.
+func New() *Pool {
+	return &Pool{workers: runtime.NumCPU(), chunkSize: 256}
+}
+
+func (p *Pool) SetWorkers(n int) {
+	if n < 1 {
+		panic("bad worker count")
+	}
+	p.workers = n
+}
+
+// SetChunkSize sets the number of consecutive items each worker takes at a
+// time. Larger chunks have less overhead; smaller ones waste less work after
+// Scan has found what it needs.
+func (p *Pool) SetChunkSize(n int) {
+	if n < 1 {
+		panic("bad chunk size")
+	}
+	p.chunkSize = n
+}
+
+func (p *Pool) orDefault() *Pool {
+	if p == nil {
+		return New()
+	}
+	return p
+}
+
+// Map returns fn applied to each element of in, in the same order as in. fn
+// is called concurrently.
+func Map[T, R any](p *Pool, in []T, fn func(T) R) []R {
+	p = p.orDefault()
+	out := make([]R, len(in))
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < p.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				lo := int(next.Add(int64(p.chunkSize))) - p.chunkSize
+				if lo >= len(in) {
+					return
+				}
+				for i := lo; i < min(lo+p.chunkSize, len(in)); i++ {
+					out[i] = fn(in[i])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return out
+}
+
+// FlatMap returns the concatenation of fn applied to each element of in, in
+// order. It's meant for expanding a search frontier into the next one.
+func FlatMap[T, R any](p *Pool, in []T, fn func(T) []R) []R {
+	out := []R{}
+	for _, rs := range Map(p, in, fn) {
+		out = append(out, rs...)
+	}
+	return out
+}
+
+// Found is a result from Scan.
+type Found[R any] struct {
+	Index int64
+	Value R
+}
+
+// Scan calls fn on start, start+1, ... concurrently, and returns the first
+// want results (by index) for which fn returned true. Work stops once they've
+// been found. Since the range is unbounded, Scan only gives up if ctx is
+// canceled, in which case it returns the results found so far along with
+// ctx's error.
+func Scan[R any](ctx context.Context, p *Pool, start int64, want int, fn func(i int64) (R, bool)) ([]Found[R], error) {
+	p = p.orDefault()
+	chunkSize := int64(p.chunkSize)
+
+	type chunkResult struct {
+		chunk int64
+		found []Found[R]
+	}
+
+	var nextChunk atomic.Int64
+	var stop atomic.Bool
+	results := make(chan chunkResult)
+
+	var wg sync.WaitGroup
+	for w := 0; w < p.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() && ctx.Err() == nil {
+				chunk := nextChunk.Add(1) - 1
+				found := []Found[R]{}
+				for i := start + chunk*chunkSize; i < start+(chunk+1)*chunkSize; i++ {
+					if stop.Load() || ctx.Err() != nil {
+						return // only chunks we don't need get abandoned
+					}
+					if v, ok := fn(i); ok {
+						found = append(found, Found[R]{Index: i, Value: v})
+					}
+				}
+				results <- chunkResult{chunk: chunk, found: found}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Chunks can finish out of order, so hold on to them until all of
+	// their predecessors are in.
+	out := []Found[R]{}
+	pending := map[int64][]Found[R]{}
+	var done int64
+	for res := range results {
+		if stop.Load() {
+			continue // draining
+		}
+
+		pending[res.chunk] = res.found
+		for found, ok := pending[done]; ok; found, ok = pending[done] {
+			delete(pending, done)
+			done++
+			out = append(out, found...)
+		}
+
+		if len(out) >= want {
+			stop.Store(true)
+		}
+	}
+
+	if len(out) >= want {
+		return out[:want], nil
+	}
+	return out, ctx.Err()
+}
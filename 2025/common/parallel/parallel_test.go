@@ -0,0 +1,122 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parallel
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPool() *Pool {
+	// Plenty of workers and small chunks, so that chunks finish out of
+	// order even on a single CPU.
+	p := New()
+	p.SetWorkers(8)
+	p.SetChunkSize(7)
+	return p
+}
+
+func TestMap(t *testing.T) {
+	in := make([]int, 1000)
+	for i := range in {
+		in[i] = i
+	}
+
+	got := Map(testPool(), in, func(v int) string { return fmt.Sprint(v * v) })
+	for i, s := range got {
+		if want := fmt.Sprint(i * i); s != want {
+			t.Fatalf("Map()[%d] = %v, want %v", i, s, want)
+		}
+	}
+
+	if got := Map(nil, []int{}, func(v int) int { return v }); len(got) != 0 {
+		t.Errorf("Map(empty) = %v, want empty", got)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	got := FlatMap(testPool(), []int{1, 2, 3}, func(v int) []int {
+		out := []int{}
+		for i := 0; i < v; i++ {
+			out = append(out, v)
+		}
+		return out
+	})
+	if want := []int{1, 2, 2, 3, 3, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FlatMap() = %v, want %v", got, want)
+	}
+}
+
+func TestScanOrder(t *testing.T) {
+	fn := func(i int64) (int64, bool) {
+		if i%2 == 0 {
+			time.Sleep(10 * time.Microsecond) // scramble completion order
+		}
+		return i * 10, i%13 == 0
+	}
+
+	got, err := Scan(context.Background(), testPool(), 5, 20, fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Found[int64]{}
+	for i := int64(5); len(want) < 20; i++ {
+		if v, ok := fn(i); ok {
+			want = append(want, Found[int64]{i, v})
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() = %v, want %v", got, want)
+	}
+}
+
+func TestScanAdventCoins(t *testing.T) {
+	// 2015/04 samples.
+	type TestCase struct {
+		key  string
+		want int64
+	}
+
+	for _, tc := range []TestCase{{"abcdef", 609043}, {"pqrstuv", 1048970}} {
+		got, err := Scan(context.Background(), nil, 1, 1, func(i int64) (string, bool) {
+			hash := fmt.Sprintf("%x", md5.Sum([]byte(fmt.Sprint(tc.key, i))))
+			return hash, strings.HasPrefix(hash, "00000")
+		})
+		if err != nil || len(got) != 1 || got[0].Index != tc.want {
+			t.Errorf("Scan(%v) = %v, %v, want index %v", tc.key, got, err, tc.want)
+		}
+	}
+}
+
+func TestScanCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	got, err := Scan(ctx, testPool(), 0, 10, func(i int64) (int64, bool) {
+		return i, i == 3
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Scan() err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if want := []Found[int64]{{3, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package perm implements permutation algebra, used to compile a fixed
+// sequence of rearrangements into something that can be inverted or repeated
+// a huge number of times cheaply.
+package perm
+
+import "github.com/simmonmt/aoc/2025/common/mtsmath"
+
+// Perm is a permutation of 0..n-1, interpreted as a function mapping i to
+// p[i].
+type Perm []int
+
+func Identity(n int) Perm {
+	p := make(Perm, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+// Then returns the permutation that applies p followed by q (i.e. q∘p).
+func (p Perm) Then(q Perm) Perm {
+	if len(p) != len(q) {
+		panic("size mismatch")
+	}
+
+	out := make(Perm, len(p))
+	for i, v := range p {
+		out[i] = q[v]
+	}
+	return out
+}
+
+func (p Perm) Inverse() Perm {
+	out := make(Perm, len(p))
+	for i, v := range p {
+		out[v] = i
+	}
+	return out
+}
+
+// Pow returns p applied n times. Negative n applies the inverse.
+func (p Perm) Pow(n int64) Perm {
+	if n < 0 {
+		// -n would overflow for MinInt64, so peel off one step.
+		inv := p.Inverse()
+		return inv.Then(inv.Pow(-(n + 1)))
+	}
+
+	out := Identity(len(p))
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			out = out.Then(p)
+		}
+		p = p.Then(p)
+	}
+	return out
+}
+
+// Cycles returns the cycle decomposition of p, including fixed points as
+// single-element cycles. Each cycle starts with its smallest element, and
+// cycles are ordered by their first element.
+func (p Perm) Cycles() [][]int {
+	seen := make([]bool, len(p))
+	out := [][]int{}
+	for i := range p {
+		if seen[i] {
+			continue
+		}
+		cycle := []int{}
+		for j := i; !seen[j]; j = p[j] {
+			seen[j] = true
+			cycle = append(cycle, j)
+		}
+		out = append(out, cycle)
+	}
+	return out
+}
+
+// Order returns the smallest positive n such that p.Pow(n) is the identity.
+func (p Perm) Order() int64 {
+	lens := []int64{1}
+	for _, c := range p.Cycles() {
+		lens = append(lens, int64(len(c)))
+	}
+	return mtsmath.LCM(lens...)
+}
+
+// Permute moves each element of in from position i to position p[i].
+func Permute[T any](p Perm, in []T) []T {
+	if len(p) != len(in) {
+		panic("size mismatch")
+	}
+
+	out := make([]T, len(in))
+	for i, v := range in {
+		out[p[i]] = v
+	}
+	return out
+}
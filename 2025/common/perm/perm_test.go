@@ -0,0 +1,207 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perm
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestPerm(t *testing.T) {
+	p := Perm{1, 2, 0, 4, 3}
+
+	if got, want := p.Then(p), (Perm{2, 0, 1, 3, 4}); !reflect.DeepEqual(got, want) {
+		t.Errorf("p.Then(p) = %v, want %v", got, want)
+	}
+	if got, want := p.Inverse(), (Perm{2, 0, 1, 4, 3}); !reflect.DeepEqual(got, want) {
+		t.Errorf("p.Inverse() = %v, want %v", got, want)
+	}
+	if got, want := p.Cycles(), [][]int{{0, 1, 2}, {3, 4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("p.Cycles() = %v, want %v", got, want)
+	}
+	if got, want := p.Order(), int64(6); got != want {
+		t.Errorf("p.Order() = %v, want %v", got, want)
+	}
+
+	slow := Identity(len(p))
+	for n := int64(0); n < 20; n++ {
+		if got := p.Pow(n); !reflect.DeepEqual(got, slow) {
+			t.Errorf("p.Pow(%v) = %v, want %v", n, got, slow)
+		}
+		if got := p.Pow(-n).Then(slow); !reflect.DeepEqual(got, Identity(len(p))) {
+			t.Errorf("p.Pow(-%v) isn't the inverse of p^%v", n, n)
+		}
+		slow = slow.Then(p)
+	}
+
+	if got, want := Permute(p, []string{"a", "b", "c", "d", "e"}), []string{"c", "a", "b", "e", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Permute = %v, want %v", got, want)
+	}
+}
+
+func TestTransformOps(t *testing.T) {
+	type TestCase struct {
+		name string
+		tr   Transform
+		want string
+	}
+
+	base := NewTransform(5)
+
+	testCases := []TestCase{
+		TestCase{"swap", base.SwapPositions(4, 0), "ebcda"},
+		TestCase{"rotate right", base.RotateRight(2), "deabc"},
+		TestCase{"rotate left", base.RotateLeft(1), "bcdea"},
+		TestCase{"rotate far", base.RotateRight(12), "deabc"},
+		TestCase{"reverse", base.Reverse(1, 3), "adcbe"},
+		TestCase{"move right", base.Move(1, 4), "acdeb"},
+		TestCase{"move left", base.Move(3, 0), "dabce"},
+		TestCase{"partner", base.Partner(1, 3), "adcbe"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.tr.ApplyString("abcde"); got != tc.want {
+				t.Errorf("ApplyString = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseDance(t *testing.T) {
+	tr, err := ParseDance("s1,x3/4,pe/b", 5)
+	if err != nil {
+		t.Fatalf("ParseDance = %v", err)
+	}
+
+	if got, want := tr.ApplyString("abcde"), "baedc"; got != want {
+		t.Errorf("once = %v, want %v", got, want)
+	}
+	if got, want := tr.Pow(2).ApplyString("abcde"), "ceadb"; got != want {
+		t.Errorf("twice = %v, want %v", got, want)
+	}
+
+	// Repeating a billion times should agree with repeating the remainder
+	// after the transform's period.
+	period := tr.Pos.Order() * tr.Label.Order()
+	if got, want := tr.Pow(1e9).ApplyString("abcde"), tr.Pow(1e9%period).ApplyString("abcde"); got != want {
+		t.Errorf("billion = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"", "q1", "x1", "pa/1", "sZ", "pa/z", "x9/1", "x-1/1"} {
+		if _, err := ParseDance(bad, 5); err == nil {
+			t.Errorf("ParseDance(%q) = _, nil, want error", bad)
+		}
+	}
+}
+
+func TestParseScramble(t *testing.T) {
+	lines := []string{
+		"swap position 4 with position 0",
+		"swap letter d with letter b",
+		"reverse positions 0 through 4",
+		"rotate left 1 step",
+		"move position 1 to position 4",
+		"move position 3 to position 0",
+	}
+
+	s, err := ParseScramble(lines, 5)
+	if err != nil {
+		t.Fatalf("ParseScramble = %v", err)
+	}
+
+	tr, ok := s.Transform()
+	if !ok {
+		t.Fatalf("Transform() = _, false, want true")
+	}
+	if got, want := tr.ApplyString("abcde"), "abdec"; got != want {
+		t.Errorf("scramble = %v, want %v", got, want)
+	}
+	if got, want := tr.Inverse().ApplyString("abdec"), "abcde"; got != want {
+		t.Errorf("unscramble = %v, want %v", got, want)
+	}
+
+	// The full sample adds position-dependent rotations.
+	lines = append(lines,
+		"rotate based on position of letter b",
+		"rotate based on position of letter d")
+	s, err = ParseScramble(lines, 5)
+	if err != nil {
+		t.Fatalf("ParseScramble = %v", err)
+	}
+	if _, ok := s.Transform(); ok {
+		t.Errorf("Transform() = _, true, want false")
+	}
+	if got, want := s.ApplyString("abcde"), "decab"; got != want {
+		t.Errorf("scramble = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{
+		"swap position 9 with position 1",
+		"swap position x with position 1",
+		"swap letter a with letter z",
+		"reverse positions 0 through 5",
+		"move position -1 to position 2",
+		"rotate based on position of letter q",
+		"rotate sideways 2",
+	} {
+		if _, err := ParseScramble([]string{bad}, 5); err == nil {
+			t.Errorf("ParseScramble(%q) = _, nil, want error", bad)
+		}
+	}
+}
+
+func TestUnscramble(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ops := []func() string{
+		func() string { return fmt.Sprintf("swap position %d with position %d", r.Intn(8), r.Intn(8)) },
+		func() string { return fmt.Sprintf("swap letter %c with letter %c", 'a'+r.Intn(8), 'a'+r.Intn(8)) },
+		func() string { return fmt.Sprintf("rotate left %d steps", r.Intn(8)) },
+		func() string { return fmt.Sprintf("rotate right %d steps", r.Intn(8)) },
+		func() string { return fmt.Sprintf("rotate based on position of letter %c", 'a'+r.Intn(8)) },
+		func() string { return fmt.Sprintf("reverse positions %d through %d", r.Intn(8), r.Intn(8)) },
+		func() string { return fmt.Sprintf("move position %d to position %d", r.Intn(8), r.Intn(8)) },
+	}
+
+	for i := 0; i < 20; i++ {
+		lines := []string{}
+		for j := 0; j < 100; j++ {
+			lines = append(lines, ops[r.Intn(len(ops))]())
+		}
+
+		s, err := ParseScramble(lines, 8)
+		if err != nil {
+			t.Fatalf("ParseScramble = %v", err)
+		}
+
+		scrambled := s.ApplyString("abcdefgh")
+		got, err := s.UnapplyString(scrambled)
+		if err != nil || got != "abcdefgh" {
+			t.Errorf("UnapplyString(%v) = %v, %v, want abcdefgh, nil",
+				scrambled, got, err)
+		}
+	}
+
+	// With 5 letters, "rotate based" isn't always invertible.
+	s, err := ParseScramble([]string{"rotate based on position of letter a"}, 5)
+	if err != nil {
+		t.Fatalf("ParseScramble = %v", err)
+	}
+	if got, err := s.UnapplyString("abcde"); err == nil {
+		t.Errorf("UnapplyString(abcde) = %v, nil, want error", got)
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perm
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Transform is a compiled sequence of rearrangements of n labels (0..n-1).
+// Operations that move positions (swap, rotate, reverse, move) and operations
+// that rename labels (partner) commute with each other, so each kind is
+// accumulated in its own permutation. Pos maps old position to new position;
+// Label maps old label to new label.
+//
+// Operations whose effect depends on the current contents (like 2016/21's
+// "rotate based on position of letter") aren't fixed permutations and can't be
+// represented; Scramble handles those.
+type Transform struct {
+	Pos, Label Perm
+}
+
+func NewTransform(n int) Transform {
+	return Transform{Pos: Identity(n), Label: Identity(n)}
+}
+
+func (t Transform) Size() int {
+	return len(t.Pos)
+}
+
+func (t Transform) thenPos(p Perm) Transform {
+	return Transform{Pos: t.Pos.Then(p), Label: t.Label}
+}
+
+// SwapPositions exchanges the elements at positions x and y.
+func (t Transform) SwapPositions(x, y int) Transform {
+	p := Identity(t.Size())
+	p[x], p[y] = y, x
+	return t.thenPos(p)
+}
+
+// RotateRight moves every element k positions to the right, wrapping around.
+// Negative k rotates left.
+func (t Transform) RotateRight(k int) Transform {
+	n := t.Size()
+	p := make(Perm, n)
+	for i := range p {
+		p[i] = ((i+k)%n + n) % n
+	}
+	return t.thenPos(p)
+}
+
+func (t Transform) RotateLeft(k int) Transform {
+	return t.RotateRight(-k)
+}
+
+// Reverse reverses the elements in positions x through y inclusive.
+func (t Transform) Reverse(x, y int) Transform {
+	if x > y {
+		x, y = y, x
+	}
+	p := Identity(t.Size())
+	for i := x; i <= y; i++ {
+		p[i] = x + y - i
+	}
+	return t.thenPos(p)
+}
+
+// Move removes the element at position x and reinserts it so that it ends up
+// at position y.
+func (t Transform) Move(x, y int) Transform {
+	p := Identity(t.Size())
+	p[x] = y
+	if x < y {
+		for i := x + 1; i <= y; i++ {
+			p[i] = i - 1
+		}
+	} else {
+		for i := y; i < x; i++ {
+			p[i] = i + 1
+		}
+	}
+	return t.thenPos(p)
+}
+
+// Partner exchanges labels a and b wherever they are.
+func (t Transform) Partner(a, b int) Transform {
+	l := Identity(t.Size())
+	l[a], l[b] = b, a
+	return Transform{Pos: t.Pos, Label: t.Label.Then(l)}
+}
+
+// Then returns the transform that applies t followed by o.
+func (t Transform) Then(o Transform) Transform {
+	return Transform{Pos: t.Pos.Then(o.Pos), Label: t.Label.Then(o.Label)}
+}
+
+func (t Transform) Inverse() Transform {
+	return Transform{Pos: t.Pos.Inverse(), Label: t.Label.Inverse()}
+}
+
+// Pow returns t applied n times. Negative n applies the inverse.
+func (t Transform) Pow(n int64) Transform {
+	return Transform{Pos: t.Pos.Pow(n), Label: t.Label.Pow(n)}
+}
+
+// Apply applies t to a sequence of labels.
+func (t Transform) Apply(in []int) []int {
+	out := Permute(t.Pos, in)
+	for i, l := range out {
+		out[i] = t.Label[l]
+	}
+	return out
+}
+
+// ApplyString applies t to a string whose letters are labels, with 'a' as
+// label 0.
+func (t Transform) ApplyString(s string) string {
+	return labelsString(t.Apply(stringLabels(s)))
+}
+
+func letterLabel(s string, n int) (int, error) {
+	if len(s) != 1 || s[0] < 'a' || int(s[0]-'a') >= n {
+		return 0, fmt.Errorf("bad letter %q", s)
+	}
+	return int(s[0] - 'a'), nil
+}
+
+func checkPositions(n int, ps ...int) error {
+	for _, p := range ps {
+		if p < 0 || p >= n {
+			return fmt.Errorf("bad position %d", p)
+		}
+	}
+	return nil
+}
+
+// ParseDance compiles a comma-separated list of 2017/16 dance moves (sX,
+// xA/B, pA/B) for n programs.
+func ParseDance(str string, n int) (Transform, error) {
+	t := NewTransform(n)
+	for _, move := range strings.Split(str, ",") {
+		if move == "" {
+			return Transform{}, fmt.Errorf("empty move")
+		}
+
+		args := strings.Split(move[1:], "/")
+		var err error
+		switch move[0] {
+		case 's':
+			var k int
+			if k, err = strconv.Atoi(args[0]); err == nil {
+				t = t.RotateRight(k)
+			}
+		case 'x':
+			var a, b int
+			if len(args) != 2 {
+				err = fmt.Errorf("want 2 args")
+			} else if a, err = strconv.Atoi(args[0]); err == nil {
+				if b, err = strconv.Atoi(args[1]); err == nil {
+					if err = checkPositions(n, a, b); err == nil {
+						t = t.SwapPositions(a, b)
+					}
+				}
+			}
+		case 'p':
+			var a, b int
+			if len(args) != 2 {
+				err = fmt.Errorf("want 2 args")
+			} else if a, err = letterLabel(args[0], n); err == nil {
+				if b, err = letterLabel(args[1], n); err == nil {
+					t = t.Partner(a, b)
+				}
+			}
+		default:
+			err = fmt.Errorf("unknown move")
+		}
+
+		if err != nil {
+			return Transform{}, fmt.Errorf("%v: %v", move, err)
+		}
+	}
+	return t, nil
+}
+
+// scrambleStep is either a fixed transform or, if rotateOn is non-negative,
+// 2016/21's rotation based on the position of label rotateOn.
+type scrambleStep struct {
+	fixed    Transform
+	rotateOn int
+}
+
+// Scramble is a sequence of 2016/21 scrambling operations. Runs of
+// operations that are fixed permutations are compiled into Transforms; the
+// position-dependent rotations between them are applied to the actual
+// contents.
+type Scramble struct {
+	n     int
+	steps []scrambleStep
+}
+
+// Transform returns the scramble as a single Transform, or false if it has
+// position-dependent steps.
+func (s *Scramble) Transform() (Transform, bool) {
+	t := NewTransform(s.n)
+	for _, step := range s.steps {
+		if step.rotateOn >= 0 {
+			return Transform{}, false
+		}
+		t = t.Then(step.fixed)
+	}
+	return t, true
+}
+
+// rotateBased rotates in right by one plus the index of label, plus one more
+// if the index is at least 4.
+func rotateBased(in []int, label int) []int {
+	idx := slices.Index(in, label)
+	k := 1 + idx
+	if idx >= 4 {
+		k++
+	}
+	return NewTransform(len(in)).RotateRight(k).Apply(in)
+}
+
+func (s *Scramble) Apply(in []int) []int {
+	out := slices.Clone(in)
+	for _, step := range s.steps {
+		if step.rotateOn >= 0 {
+			out = rotateBased(out, step.rotateOn)
+		} else {
+			out = step.fixed.Apply(out)
+		}
+	}
+	return out
+}
+
+// Unapply returns the input that Apply would turn into out. Position-based
+// rotations are inverted by trying every rotation, which fails if none or
+// more than one of them works (as can happen with lengths other than 8).
+func (s *Scramble) Unapply(out []int) ([]int, error) {
+	in := slices.Clone(out)
+	for i := len(s.steps) - 1; i >= 0; i-- {
+		step := s.steps[i]
+		if step.rotateOn < 0 {
+			in = step.fixed.Inverse().Apply(in)
+			continue
+		}
+
+		var found []int
+		for k := 0; k < s.n; k++ {
+			cand := NewTransform(s.n).RotateLeft(k).Apply(in)
+			if !slices.Equal(rotateBased(cand, step.rotateOn), in) {
+				continue
+			}
+			if found != nil && !slices.Equal(found, cand) {
+				return nil, fmt.Errorf("step %d: ambiguous rotation", i+1)
+			}
+			found = cand
+		}
+		if found == nil {
+			return nil, fmt.Errorf("step %d: no rotation matches", i+1)
+		}
+		in = found
+	}
+	return in, nil
+}
+
+func stringLabels(s string) []int {
+	out := make([]int, len(s))
+	for i, r := range s {
+		out[i] = int(r - 'a')
+	}
+	return out
+}
+
+func labelsString(ls []int) string {
+	out := []byte{}
+	for _, l := range ls {
+		out = append(out, byte('a'+l))
+	}
+	return string(out)
+}
+
+// ApplyString scrambles a string whose letters are labels, with 'a' as label
+// 0.
+func (s *Scramble) ApplyString(in string) string {
+	return labelsString(s.Apply(stringLabels(in)))
+}
+
+// UnapplyString unscrambles a string whose letters are labels.
+func (s *Scramble) UnapplyString(out string) (string, error) {
+	in, err := s.Unapply(stringLabels(out))
+	if err != nil {
+		return "", err
+	}
+	return labelsString(in), nil
+}
+
+// ParseScramble compiles 2016/21 scrambling operations for a password of
+// length n.
+func ParseScramble(lines []string, n int) (*Scramble, error) {
+	s := &Scramble{n: n}
+	addFixed := func(op func(t Transform) Transform) {
+		if len(s.steps) == 0 || s.steps[len(s.steps)-1].rotateOn >= 0 {
+			s.steps = append(s.steps, scrambleStep{fixed: NewTransform(n), rotateOn: -1})
+		}
+		last := &s.steps[len(s.steps)-1]
+		last.fixed = op(last.fixed)
+	}
+
+	for i, line := range lines {
+		var x, y int
+		var a, b string
+		var err error
+
+		switch {
+		case strings.HasPrefix(line, "swap position"):
+			if _, err = fmt.Sscanf(line, "swap position %d with position %d", &x, &y); err == nil {
+				if err = checkPositions(n, x, y); err == nil {
+					addFixed(func(t Transform) Transform { return t.SwapPositions(x, y) })
+				}
+			}
+		case strings.HasPrefix(line, "swap letter"):
+			if _, err = fmt.Sscanf(line, "swap letter %s with letter %s", &a, &b); err == nil {
+				var la, lb int
+				if la, err = letterLabel(a, n); err == nil {
+					if lb, err = letterLabel(b, n); err == nil {
+						addFixed(func(t Transform) Transform { return t.Partner(la, lb) })
+					}
+				}
+			}
+		case strings.HasPrefix(line, "rotate left"):
+			if _, err = fmt.Sscanf(line, "rotate left %d", &x); err == nil {
+				addFixed(func(t Transform) Transform { return t.RotateLeft(x) })
+			}
+		case strings.HasPrefix(line, "rotate right"):
+			if _, err = fmt.Sscanf(line, "rotate right %d", &x); err == nil {
+				addFixed(func(t Transform) Transform { return t.RotateRight(x) })
+			}
+		case strings.HasPrefix(line, "rotate based"):
+			if _, err = fmt.Sscanf(line, "rotate based on position of letter %s", &a); err == nil {
+				var la int
+				if la, err = letterLabel(a, n); err == nil {
+					s.steps = append(s.steps, scrambleStep{rotateOn: la})
+				}
+			}
+		case strings.HasPrefix(line, "reverse positions"):
+			if _, err = fmt.Sscanf(line, "reverse positions %d through %d", &x, &y); err == nil {
+				if err = checkPositions(n, x, y); err == nil {
+					addFixed(func(t Transform) Transform { return t.Reverse(x, y) })
+				}
+			}
+		case strings.HasPrefix(line, "move position"):
+			if _, err = fmt.Sscanf(line, "move position %d to position %d", &x, &y); err == nil {
+				if err = checkPositions(n, x, y); err == nil {
+					addFixed(func(t Transform) Transform { return t.Move(x, y) })
+				}
+			}
+		default:
+			err = fmt.Errorf("unsupported operation")
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%d: %v", i+1, err)
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package poly fits polynomials to sample points exactly (over the
+// rationals) and evaluates them far outside the sampled range, for puzzles
+// whose answers grow polynomially.
+package poly
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/simmonmt/aoc/2025/common/mtsmath"
+)
+
+// Poly is a polynomial with Poly[i] as the coefficient of x^i. The canonical
+// form has no trailing zero coefficients; the zero polynomial is empty.
+type Poly []mtsmath.Rat
+
+func trim(p Poly) Poly {
+	for len(p) > 0 && p[len(p)-1].IsZero() {
+		p = p[:len(p)-1]
+	}
+	return p
+}
+
+// Degree returns the degree of p, or -1 for the zero polynomial.
+func (p Poly) Degree() int {
+	return len(trim(p)) - 1
+}
+
+// Eval returns p(x) using Horner's method. Intermediate values are promoted
+// to big numbers as needed, so x can be arbitrarily large.
+func (p Poly) Eval(x mtsmath.Rat) mtsmath.Rat {
+	var out mtsmath.Rat
+	for i := len(p) - 1; i >= 0; i-- {
+		out = out.Mul(x).Add(p[i])
+	}
+	return out
+}
+
+func (p Poly) EvalInt(x int64) mtsmath.Rat {
+	return p.Eval(mtsmath.RatFromInt(x))
+}
+
+func (p Poly) String() string {
+	terms := []string{}
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].IsZero() {
+			continue
+		}
+		switch i {
+		case 0:
+			terms = append(terms, p[i].String())
+		case 1:
+			terms = append(terms, fmt.Sprintf("%v·x", p[i]))
+		default:
+			terms = append(terms, fmt.Sprintf("%v·x^%d", p[i], i))
+		}
+	}
+	if len(terms) == 0 {
+		return "0"
+	}
+	return strings.Join(terms, " + ")
+}
+
+// Interpolate returns the unique polynomial of degree < len(xs) passing
+// through each (xs[i], ys[i]), using Newton's divided differences. The xs
+// must be distinct.
+func Interpolate(xs, ys []mtsmath.Rat) Poly {
+	if len(xs) != len(ys) {
+		panic("length mismatch")
+	}
+	n := len(xs)
+	if n == 0 {
+		return Poly{}
+	}
+
+	// After this loop coeffs[k] is the divided difference f[x0, ..., xk].
+	coeffs := append([]mtsmath.Rat(nil), ys...)
+	for level := 1; level < n; level++ {
+		for i := n - 1; i >= level; i-- {
+			dx := xs[i].Sub(xs[i-level])
+			if dx.IsZero() {
+				panic(fmt.Sprintf("duplicate x %v", xs[i]))
+			}
+			coeffs[i] = coeffs[i].Sub(coeffs[i-1]).Div(dx)
+		}
+	}
+
+	// Expand the Newton form c0 + c1(x-x0) + c2(x-x0)(x-x1) + ... from the
+	// innermost term outward.
+	out := Poly{coeffs[n-1]}
+	for k := n - 2; k >= 0; k-- {
+		// out = out·(x - xs[k]) + coeffs[k]
+		next := make(Poly, len(out)+1)
+		for i, c := range out {
+			next[i+1] = next[i+1].Add(c)
+			next[i] = next[i].Sub(c.Mul(xs[k]))
+		}
+		next[0] = next[0].Add(coeffs[k])
+		out = next
+	}
+	return trim(out)
+}
+
+// Fit returns the lowest-degree polynomial passing through every
+// (xs[i], ys[i]), or false if that polynomial would have degree greater than
+// maxDegree. Only points beyond the first maxDegree+1 can show that data isn't
+// polynomial, so supply extras as a check.
+func Fit(xs, ys []mtsmath.Rat, maxDegree int) (Poly, bool) {
+	if len(xs) != len(ys) {
+		panic("length mismatch")
+	}
+
+	n := min(len(xs), maxDegree+1)
+	p := Interpolate(xs[:n], ys[:n])
+	for i := n; i < len(xs); i++ {
+		if !p.Eval(xs[i]).Equal(ys[i]) {
+			return nil, false
+		}
+	}
+	return p, true
+}
+
+// SequenceDegree returns the degree of the polynomial generating seq (taken
+// to be p(0), p(1), ...) by repeatedly taking finite differences until they
+// are all zero. Returns false if seq runs out before that's confirmed, which
+// requires at least degree+2 values.
+func SequenceDegree(seq []mtsmath.Rat) (int, bool) {
+	cur := seq
+	for degree := -1; len(cur) > 0; degree++ {
+		allZero := true
+		for _, v := range cur {
+			if !v.IsZero() {
+				allZero = false
+				break
+			}
+		}
+		if allZero {
+			return max(degree, 0), true
+		}
+
+		next := make([]mtsmath.Rat, len(cur)-1)
+		for i := range next {
+			next[i] = cur[i+1].Sub(cur[i])
+		}
+		cur = next
+	}
+	return 0, false
+}
+
+// FitSequence returns the polynomial p such that p(i) = seq[i], or false if
+// seq isn't (provably) polynomial. See SequenceDegree.
+func FitSequence(seq []mtsmath.Rat) (Poly, bool) {
+	degree, ok := SequenceDegree(seq)
+	if !ok {
+		return nil, false
+	}
+
+	xs := make([]mtsmath.Rat, degree+1)
+	for i := range xs {
+		xs[i] = mtsmath.RatFromInt(int64(i))
+	}
+	return Interpolate(xs, seq[:degree+1]), true
+}
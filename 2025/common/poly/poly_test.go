@@ -0,0 +1,124 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poly
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/mtsmath"
+)
+
+func TestInterpolate(t *testing.T) {
+	type TestCase struct {
+		xs, ys []int64
+		want   string
+	}
+
+	testCases := []TestCase{
+		TestCase{xs: []int64{}, ys: []int64{}, want: "0"},
+		TestCase{xs: []int64{4}, ys: []int64{7}, want: "7"},
+		TestCase{xs: []int64{0, 1, 2}, ys: []int64{7, 15, 29}, want: "3·x^2 + 5·x + 7"},
+		TestCase{xs: []int64{-1, 3, 10}, ys: []int64{5, 17, 38}, want: "3·x + 8"},
+		TestCase{xs: []int64{0, 2}, ys: []int64{0, 1}, want: "1/2·x"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			xs, ys := mtsmath.RatVector(tc.xs), mtsmath.RatVector(tc.ys)
+			p := Interpolate(xs, ys)
+			if got := p.String(); got != tc.want {
+				t.Errorf("Interpolate(%v, %v) = %v, want %v", tc.xs, tc.ys, got, tc.want)
+			}
+			for i, x := range xs {
+				if got := p.Eval(x); !got.Equal(ys[i]) {
+					t.Errorf("p(%v) = %v, want %v", x, got, ys[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFit(t *testing.T) {
+	xs := mtsmath.RatVector([]int64{0, 1, 2, 3, 4})
+
+	p, ok := Fit(xs, mtsmath.RatVector([]int64{1, 3, 5, 7, 9}), 3)
+	if !ok || p.String() != "2·x + 1" {
+		t.Errorf("Fit(linear) = %v, %v, want 2·x + 1, true", p, ok)
+	}
+
+	if p, ok := Fit(xs, mtsmath.RatVector([]int64{1, 2, 4, 8, 16}), 2); ok {
+		t.Errorf("Fit(exponential) = %v, true, want false", p)
+	}
+}
+
+func TestExtrapolateSequence(t *testing.T) {
+	// 2023/09 sample: the next value, and the one before the first.
+	type TestCase struct {
+		seq        []int64
+		degree     int
+		prev, next int64
+	}
+
+	testCases := []TestCase{
+		TestCase{seq: []int64{0, 3, 6, 9, 12, 15}, degree: 1, prev: -3, next: 18},
+		TestCase{seq: []int64{1, 3, 6, 10, 15, 21}, degree: 2, prev: 0, next: 28},
+		TestCase{seq: []int64{10, 13, 16, 21, 30, 45}, degree: 3, prev: 5, next: 68},
+	}
+
+	for _, tc := range testCases {
+		seq := mtsmath.RatVector(tc.seq)
+		if got, ok := SequenceDegree(seq); !ok || got != tc.degree {
+			t.Errorf("SequenceDegree(%v) = %v, %v, want %v, true", tc.seq, got, ok, tc.degree)
+		}
+
+		p, ok := FitSequence(seq)
+		if !ok {
+			t.Errorf("FitSequence(%v) = _, false, want true", tc.seq)
+			continue
+		}
+		if got := p.EvalInt(-1); !got.Equal(mtsmath.RatFromInt(tc.prev)) {
+			t.Errorf("p(-1) = %v, want %v", got, tc.prev)
+		}
+		if got := p.EvalInt(int64(len(seq))); !got.Equal(mtsmath.RatFromInt(tc.next)) {
+			t.Errorf("p(%d) = %v, want %v", len(seq), got, tc.next)
+		}
+	}
+
+	// Too short to confirm the degree.
+	if _, ok := SequenceDegree(mtsmath.RatVector([]int64{1, 2, 4})); ok {
+		t.Errorf("SequenceDegree(1 2 4) = _, true, want false")
+	}
+}
+
+func TestEvalHuge(t *testing.T) {
+	// The 2023/21 shape: sample at three points, then evaluate far away.
+	// f(k) = 14840·k² + 14950·k + 3701
+	p := Interpolate(mtsmath.RatVector([]int64{0, 1, 2}),
+		mtsmath.RatVector([]int64{3701, 33491, 92961}))
+	if got, want := p.EvalInt(202300).String(), "607334327988701"; got != want {
+		t.Errorf("p(202300) = %v, want %v", got, want)
+	}
+
+	// Something that overflows int64.
+	x := big.NewInt(1 << 40)
+	want := new(big.Int).Mul(x, x)
+	want.Mul(want, big.NewInt(14840))
+	want.Add(want, new(big.Int).Mul(x, big.NewInt(14950)))
+	want.Add(want, big.NewInt(3701))
+	if got := p.EvalInt(1 << 40); got.Num().Cmp(want) != 0 || !got.IsInt() {
+		t.Errorf("p(2^40) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package search holds general-purpose search algorithms over implicit state
+// spaces, where states are generated on demand by a neighbor function rather
+// than being known up front.
+package search
+
+import "github.com/simmonmt/aoc/2025/common/logger"
+
+// BFS is a breadth-first search over unit-cost edges.
+type BFS[S comparable] struct {
+	neighbors func(S) []S
+	goal      func(S) bool
+	maxDepth  int
+}
+
+func NewBFS[S comparable](neighbors func(S) []S) *BFS[S] {
+	return &BFS[S]{neighbors: neighbors, maxDepth: -1}
+}
+
+// SetGoal makes Run stop as soon as it finds a state for which goal returns
+// true.
+func (b *BFS[S]) SetGoal(goal func(S) bool) {
+	b.goal = goal
+}
+
+// SetMaxDepth stops the search from exploring states more than maxDepth steps
+// from the start. A negative value (the default) means no limit.
+func (b *BFS[S]) SetMaxDepth(maxDepth int) {
+	b.maxDepth = maxDepth
+}
+
+type BFSResult[S comparable] struct {
+	// Dist holds the distance to every state found. If the search stopped
+	// early at a goal, states further away than the goal may be missing.
+	Dist   map[S]int
+	Parent map[S]S
+
+	Found bool // whether a goal was found
+	Goal  S    // the goal, if Found
+}
+
+// Run searches outward from starts, all of which are at distance zero.
+func (b *BFS[S]) Run(starts ...S) *BFSResult[S] {
+	r := &BFSResult[S]{Dist: map[S]int{}, Parent: map[S]S{}}
+
+	queue := []S{}
+	for _, s := range starts {
+		if _, found := r.Dist[s]; found {
+			continue
+		}
+		r.Dist[s] = 0
+		if b.goal != nil && b.goal(s) {
+			r.Found, r.Goal = true, s
+			return r
+		}
+		queue = append(queue, s)
+	}
+
+	progress := logger.NewProgress("bfs", "expanded")
+	progress.Gauge("queue", func() int64 { return int64(len(queue)) })
+	progress.Gauge("seen", func() int64 { return int64(len(r.Dist)) })
+	defer progress.Done()
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		progress.Add(1)
+
+		dist := r.Dist[cur] + 1
+		if b.maxDepth >= 0 && dist > b.maxDepth {
+			continue
+		}
+
+		for _, next := range b.neighbors(cur) {
+			if _, found := r.Dist[next]; found {
+				continue
+			}
+			r.Dist[next] = dist
+			r.Parent[next] = cur
+
+			if b.goal != nil && b.goal(next) {
+				r.Found, r.Goal = true, next
+				return r
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return r
+}
+
+// PathTo returns the states on a shortest path from a start to s (both
+// inclusive), or nil if s wasn't reached.
+func (r *BFSResult[S]) PathTo(s S) []S {
+	if _, found := r.Dist[s]; !found {
+		return nil
+	}
+
+	path := make([]S, r.Dist[s]+1)
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i] = s
+		s = r.Parent[s]
+	}
+	return path
+}
+
+// Path returns the path to the goal, or nil if no goal was found.
+func (r *BFSResult[S]) Path() []S {
+	if !r.Found {
+		return nil
+	}
+	return r.PathTo(r.Goal)
+}
+
+// Within returns the number of states at most n steps from a start.
+func (r *BFSResult[S]) Within(n int) int {
+	num := 0
+	for _, d := range r.Dist {
+		if d <= n {
+			num++
+		}
+	}
+	return num
+}
+
+// ReachableIn returns the number of states that can be occupied after
+// exactly n steps, assuming every step can be undone (so a state at distance
+// d can be revisited at d+2, d+4, ...) and that the state graph is bipartite
+// (so it can't be occupied at d+1, d+3, ...). Grids moving in the four
+// cardinal directions satisfy both.
+func (r *BFSResult[S]) ReachableIn(n int) int {
+	num := 0
+	for _, d := range r.Dist {
+		if d <= n && d%2 == n%2 {
+			num++
+		}
+	}
+	return num
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"crypto/md5"
+	"fmt"
+	"math/bits"
+	"strings"
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/grid"
+	"github.com/simmonmt/aoc/2025/common/pos"
+)
+
+func officeNeighbors(fav int) func(pos.P2) []pos.P2 {
+	isOpen := func(p pos.P2) bool {
+		x, y := p.X, p.Y
+		if x < 0 || y < 0 {
+			return false
+		}
+		return bits.OnesCount(uint(x*x+3*x+2*x*y+y+y*y+fav))%2 == 0
+	}
+
+	return func(p pos.P2) []pos.P2 {
+		out := []pos.P2{}
+		for _, n := range p.AllNeighbors(false) {
+			if isOpen(n) {
+				out = append(out, n)
+			}
+		}
+		return out
+	}
+}
+
+func TestBFSMaze(t *testing.T) {
+	// 2016/13 sample.
+	start, goal := pos.P2{X: 1, Y: 1}, pos.P2{X: 7, Y: 4}
+
+	b := NewBFS(officeNeighbors(10))
+	b.SetGoal(func(p pos.P2) bool { return p == goal })
+	r := b.Run(start)
+
+	if !r.Found || r.Goal != goal {
+		t.Fatalf("Run() found %v %v, want true %v", r.Found, r.Goal, goal)
+	}
+	path := r.Path()
+	if len(path) != 12 || path[0] != start || path[11] != goal {
+		t.Errorf("Path() = %v, want 12 steps from %v to %v", path, start, goal)
+	}
+	for i := 1; i < len(path); i++ {
+		if path[i].ManhattanDistance(path[i-1]) != 1 {
+			t.Errorf("Path() has a jump from %v to %v", path[i-1], path[i])
+		}
+	}
+
+	b = NewBFS(officeNeighbors(10))
+	b.SetMaxDepth(3)
+	r = b.Run(start)
+	for p, d := range r.Dist {
+		if d > 3 {
+			t.Errorf("Dist[%v] = %v, beyond max depth", p, d)
+		}
+	}
+	if got := r.PathTo(goal); got != nil {
+		t.Errorf("PathTo(%v) = %v, want nil", goal, got)
+	}
+}
+
+func TestBFSVault(t *testing.T) {
+	// 2016/17: the state is the path taken so far.
+	type TestCase struct {
+		passcode string
+		want     string
+	}
+
+	testCases := []TestCase{
+		TestCase{"ihgpwlah", "DDRRRD"},
+		TestCase{"kglvqrro", "DDUDRLRRUDRD"},
+		TestCase{"ulqzkmiv", "DRURDRUDDLLDLUURRDULRLDUUDDDRR"},
+	}
+
+	location := func(path string) pos.P2 {
+		p := pos.P2{}
+		for _, r := range path {
+			switch r {
+			case 'U':
+				p.Y--
+			case 'D':
+				p.Y++
+			case 'L':
+				p.X--
+			case 'R':
+				p.X++
+			}
+		}
+		return p
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.passcode, func(t *testing.T) {
+			b := NewBFS(func(path string) []string {
+				hash := fmt.Sprintf("%x", md5.Sum([]byte(tc.passcode+path)))
+				out := []string{}
+				for i, dir := range "UDLR" {
+					np := location(path + string(dir))
+					if strings.IndexByte("bcdef", hash[i]) < 0 || np.X < 0 || np.X > 3 || np.Y < 0 || np.Y > 3 {
+						continue
+					}
+					out = append(out, path+string(dir))
+				}
+				return out
+			})
+			b.SetGoal(func(path string) bool { return location(path) == pos.P2{X: 3, Y: 3} })
+
+			if r := b.Run(""); r.Goal != tc.want {
+				t.Errorf("Run() goal = %v, want %v", r.Goal, tc.want)
+			}
+		})
+	}
+}
+
+func TestBFSGardenPlots(t *testing.T) {
+	// 2023/21 sample.
+	lines := []string{
+		"...........",
+		".....###.#.",
+		".###.##..#.",
+		"..#.#...#..",
+		"....#.#....",
+		".##..S####.",
+		".##..#...#.",
+		".......##..",
+		".##.#.####.",
+		".##..##.##.",
+		"...........",
+	}
+
+	var start pos.P2
+	g, err := grid.NewFromLines(lines, func(p pos.P2, r rune) (rune, error) {
+		if r == 'S' {
+			start = p
+		}
+		return r, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewBFS(func(p pos.P2) []pos.P2 {
+		out := []pos.P2{}
+		for _, n := range g.AllNeighbors(p, false) {
+			if v, _ := g.Get(n); v != '#' {
+				out = append(out, n)
+			}
+		}
+		return out
+	}).Run(start)
+
+	if got := r.ReachableIn(6); got != 16 {
+		t.Errorf("ReachableIn(6) = %v, want 16", got)
+	}
+	if got := r.Within(1); got != 3 {
+		t.Errorf("Within(1) = %v, want 3", got)
+	}
+}
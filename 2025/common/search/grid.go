@@ -0,0 +1,162 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"fmt"
+
+	"github.com/simmonmt/aoc/2025/common/astar"
+	"github.com/simmonmt/aoc/2025/common/dir"
+	"github.com/simmonmt/aoc/2025/common/grid"
+	"github.com/simmonmt/aoc/2025/common/pos"
+)
+
+// GridState is a position on a grid along with the direction of the last
+// move and the number of consecutive moves made in that direction. A Dir of
+// DIR_UNKNOWN (with Run 0) means no direction has been chosen yet, so the
+// first move may go any way without penalty.
+type GridState struct {
+	Pos pos.P2
+	Dir dir.Dir
+	Run int
+}
+
+func (s GridState) String() string {
+	d := "?"
+	if s.Dir != dir.DIR_UNKNOWN {
+		d = s.Dir.String()
+	}
+	return fmt.Sprintf("%v%s%d", s.Pos, d, s.Run)
+}
+
+// GridRules describes how a walker moves around a grid.
+type GridRules[T any] struct {
+	// Enter returns the cost of moving into p, which contains v, or false if
+	// p can't be entered. Required.
+	Enter func(p pos.P2, v T) (uint, bool)
+
+	// TurnCost is added for each 90 degree turn. Reversing counts as two
+	// turns.
+	TurnCost uint
+
+	// MinStraight is the number of moves that must be made in one direction
+	// before turning or stopping at the end. MaxStraight is the most that
+	// can be made before turning. Zero means no limit.
+	MinStraight, MaxStraight int
+
+	AllowReverse bool
+
+	// MinEnterCost is a lower bound on Enter's cost, used to make the A*
+	// estimate. Zero is always safe, but makes the search a Dijkstra.
+	MinEnterCost uint
+}
+
+type gridClient[T any] struct {
+	g     *grid.Grid[T]
+	end   pos.P2
+	rules GridRules[T]
+}
+
+// turnCost returns the cost of changing from direction from to direction to,
+// or false if the change isn't allowed.
+func (c *gridClient[T]) turnCost(from GridState, to dir.Dir) (uint, bool) {
+	switch {
+	case from.Dir == dir.DIR_UNKNOWN || from.Dir == to:
+		return 0, true
+	case from.Run > 0 && from.Run < c.rules.MinStraight:
+		return 0, false
+	case to == from.Dir.Reverse():
+		return 2 * c.rules.TurnCost, c.rules.AllowReverse
+	default:
+		return c.rules.TurnCost, true
+	}
+}
+
+func (c *gridClient[T]) AllNeighbors(cur GridState) []GridState {
+	out := []GridState{}
+	for _, d := range dir.AllDirs {
+		if _, ok := c.turnCost(cur, d); !ok {
+			continue
+		}
+
+		run := 1
+		if d == cur.Dir {
+			if c.rules.MaxStraight > 0 && cur.Run >= c.rules.MaxStraight {
+				continue
+			}
+			run = cur.Run + 1
+		}
+
+		p := d.From(cur.Pos)
+		v, found := c.g.Get(p)
+		if !found {
+			continue
+		}
+		if _, ok := c.rules.Enter(p, v); !ok {
+			continue
+		}
+
+		out = append(out, GridState{Pos: p, Dir: d, Run: run})
+	}
+	return out
+}
+
+func (c *gridClient[T]) EstimateDistance(start, end GridState) uint {
+	return uint(start.Pos.ManhattanDistance(c.end)) * c.rules.MinEnterCost
+}
+
+func (c *gridClient[T]) NeighborDistance(n1, n2 GridState) uint {
+	turn, _ := c.turnCost(n1, n2.Dir)
+	v, _ := c.g.Get(n2.Pos)
+	enter, _ := c.rules.Enter(n2.Pos, v)
+	return turn + enter
+}
+
+func (c *gridClient[T]) GoalReached(cand, goal GridState) bool {
+	return cand.Pos == c.end && cand.Run >= c.rules.MinStraight
+}
+
+func (c *gridClient[T]) Serialize(s GridState) string {
+	return fmt.Sprintf("%d,%d,%d,%d", s.Pos.X, s.Pos.Y, s.Dir, s.Run)
+}
+
+func (c *gridClient[T]) Deserialize(str string) (GridState, error) {
+	s := GridState{}
+	if _, err := fmt.Sscanf(str, "%d,%d,%d,%d", &s.Pos.X, &s.Pos.Y, &s.Dir, &s.Run); err != nil {
+		return GridState{}, err
+	}
+	return s, nil
+}
+
+// GridPath finds the cheapest way to get from start to end on g, moving in
+// the four cardinal directions according to rules. It returns the cost and
+// the states along the path (start first), or false if end can't be reached.
+func GridPath[T any](g *grid.Grid[T], start GridState, end pos.P2, rules GridRules[T]) (uint, []GridState, bool) {
+	client := &gridClient[T]{g: g, end: end, rules: rules}
+	revPath := astar.New[GridState](start, GridState{Pos: end}, client).Solve()
+	if revPath == nil {
+		return 0, nil, false
+	}
+
+	path := make([]GridState, len(revPath))
+	cost := uint(0)
+	for i, s := range revPath {
+		path[len(path)-1-i] = s
+		if i > 0 {
+			cost += client.NeighborDistance(s, revPath[i-1])
+		}
+	}
+	return cost, path, true
+}
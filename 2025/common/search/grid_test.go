@@ -0,0 +1,153 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/dir"
+	"github.com/simmonmt/aoc/2025/common/grid"
+	"github.com/simmonmt/aoc/2025/common/pos"
+)
+
+func mustGrid(t *testing.T, lines []string) *grid.Grid[rune] {
+	t.Helper()
+	g, err := grid.NewFromLines(lines, grid.RuneMapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func findRune(g *grid.Grid[rune], want rune) pos.P2 {
+	var out pos.P2
+	g.Walk(func(p pos.P2, r rune) {
+		if r == want {
+			out = p
+		}
+	})
+	return out
+}
+
+func TestGridPathCrucible(t *testing.T) {
+	// 2023/17 samples.
+	heatLoss := func(p pos.P2, r rune) (uint, bool) { return uint(r - '0'), true }
+
+	type TestCase struct {
+		name     string
+		lines    []string
+		min, max int
+		want     uint
+	}
+
+	sample1 := []string{
+		"2413432311323",
+		"3215453535623",
+		"3255245654254",
+		"3446585845452",
+		"4546657867536",
+		"1438598798454",
+		"4457876987766",
+		"3637877979653",
+		"4654967986887",
+		"4564679986453",
+		"1224686865563",
+		"2546548887735",
+		"4322674655533",
+	}
+	sample2 := []string{
+		"111111111111",
+		"999999999991",
+		"999999999991",
+		"999999999991",
+		"999999999991",
+	}
+
+	testCases := []TestCase{
+		TestCase{name: "crucible", lines: sample1, min: 0, max: 3, want: 102},
+		TestCase{name: "ultra", lines: sample1, min: 4, max: 10, want: 94},
+		TestCase{name: "ultra2", lines: sample2, min: 4, max: 10, want: 71},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := mustGrid(t, tc.lines)
+			end := pos.P2{X: g.Width() - 1, Y: g.Height() - 1}
+			rules := GridRules[rune]{
+				Enter:        heatLoss,
+				MinStraight:  tc.min,
+				MaxStraight:  tc.max,
+				MinEnterCost: 1,
+			}
+
+			cost, path, ok := GridPath(g, GridState{}, end, rules)
+			if !ok || cost != tc.want {
+				t.Fatalf("GridPath() = %v, _, %v, want %v, _, true", cost, ok, tc.want)
+			}
+			if path[0].Pos != (pos.P2{}) || path[len(path)-1].Pos != end {
+				t.Errorf("path runs from %v to %v, want %v to %v",
+					path[0], path[len(path)-1], pos.P2{}, end)
+			}
+			for _, s := range path {
+				if (tc.max > 0 && s.Run > tc.max) || s.Run < 0 {
+					t.Errorf("path has bad run in %v", s)
+				}
+			}
+		})
+	}
+}
+
+func TestGridPathReindeer(t *testing.T) {
+	// 2024/16 sample.
+	g := mustGrid(t, []string{
+		"###############",
+		"#.......#....E#",
+		"#.#.###.#.###.#",
+		"#.....#.#...#.#",
+		"#.###.#####.#.#",
+		"#.#.#.......#.#",
+		"#.#.#####.###.#",
+		"#...........#.#",
+		"###.#.#####.#.#",
+		"#...#.....#.#.#",
+		"#.#.#.###.#.#.#",
+		"#.....#...#.#.#",
+		"#.###.#.#.#.#.#",
+		"#S..#.....#...#",
+		"###############",
+	})
+
+	rules := GridRules[rune]{
+		Enter: func(p pos.P2, r rune) (uint, bool) {
+			return 1, r != '#'
+		},
+		TurnCost:     1000,
+		AllowReverse: true,
+		MinEnterCost: 1,
+	}
+
+	start := GridState{Pos: findRune(g, 'S'), Dir: dir.DIR_EAST}
+	cost, _, ok := GridPath(g, start, findRune(g, 'E'), rules)
+	if !ok || cost != 7036 {
+		t.Errorf("GridPath() = %v, _, %v, want 7036, _, true", cost, ok)
+	}
+
+	// Walled in.
+	g.Set(pos.P2{X: 13, Y: 2}, '#')
+	g.Set(pos.P2{X: 12, Y: 1}, '#')
+	if _, _, ok := GridPath(g, start, findRune(g, 'E'), rules); ok {
+		t.Errorf("GridPath() found a path to an unreachable end")
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/simmonmt/aoc/2025/common/logger"
+)
+
+// Optimizer searches a tree (or DAG) of states for the one with the highest
+// value, using branch and bound: subtrees whose upper bound can't beat the
+// best value found so far are skipped. To minimize, negate the values.
+type Optimizer[S comparable] struct {
+	children func(S) []S
+	value    func(S) int
+
+	bound     func(S) int
+	domKey    func(S) string
+	dominates func(a, b S) bool
+	memo      bool
+
+	beamWidth int
+	maxNodes  int
+	timeLimit time.Duration
+}
+
+// NewOptimizer returns an optimizer that explores states using children and
+// scores each state it visits with value. Every visited state is a candidate
+// solution.
+func NewOptimizer[S comparable](children func(S) []S, value func(S) int) *Optimizer[S] {
+	return &Optimizer[S]{children: children, value: value}
+}
+
+// SetBound supplies an upper bound on the value of s and every state that can
+// be reached from it. The tighter the bound, the more gets pruned; a bound
+// that's ever too low will make the search miss the optimum.
+func (o *Optimizer[S]) SetBound(bound func(S) int) {
+	o.bound = bound
+}
+
+// SetDominance enables dominance pruning. States with the same key are
+// compared, and a state is pruned if a previously-seen state dominates it
+// (i.e. is known to be at least as good). dominates must be reflexive.
+func (o *Optimizer[S]) SetDominance(key func(S) string, dominates func(a, b S) bool) {
+	o.domKey = key
+	o.dominates = dominates
+}
+
+// SetMemo makes the search skip states it has already visited. Only useful
+// when the same state can be reached by different routes.
+func (o *Optimizer[S]) SetMemo(memo bool) {
+	o.memo = memo
+}
+
+// SetBeamWidth switches from depth-first search to beam search, which
+// explores the tree a level at a time, keeping only the width most promising
+// states (by bound if one was set, otherwise by value) at each level. Beam
+// search is a heuristic and may miss the optimum. Zero (the default)
+// disables it.
+func (o *Optimizer[S]) SetBeamWidth(width int) {
+	o.beamWidth = width
+}
+
+// SetMaxNodes stops the search after expanding n states. Zero (the default)
+// means no limit.
+func (o *Optimizer[S]) SetMaxNodes(n int) {
+	o.maxNodes = n
+}
+
+// SetTimeLimit stops the search after d. Zero (the default) means no limit.
+func (o *Optimizer[S]) SetTimeLimit(d time.Duration) {
+	o.timeLimit = d
+}
+
+type OptimizerStats struct {
+	Expanded        int // states whose children were generated
+	PrunedBound     int // states skipped because of their bound
+	PrunedDominated int // states skipped because another dominated them
+	PrunedSeen      int // states skipped because they'd been seen (memo)
+	BeamDropped     int // states dropped for not making the beam
+
+	// Complete is false if the search was cut short by a node or time
+	// limit, or if beam search dropped any states. Results from a complete
+	// search are optimal.
+	Complete bool
+}
+
+type OptimizerResult[S comparable] struct {
+	Best  S
+	Value int
+	Stats OptimizerStats
+}
+
+type optimizerRun[S comparable] struct {
+	o        *Optimizer[S]
+	best     S
+	value    int
+	stats    OptimizerStats
+	seen     map[S]bool
+	frontier map[string][]S // non-dominated states by dominance key
+	deadline time.Time
+	progress *logger.Progress
+}
+
+// outOfBudget returns true if the search should stop.
+func (r *optimizerRun[S]) outOfBudget() bool {
+	if r.o.maxNodes > 0 && r.stats.Expanded >= r.o.maxNodes {
+		return true
+	}
+	// Checking the clock is comparatively slow, so only do it
+	// periodically.
+	if !r.deadline.IsZero() && r.stats.Expanded%1024 == 0 && time.Now().After(r.deadline) {
+		return true
+	}
+	return false
+}
+
+// visit scores s and decides whether it's worth exploring further.
+func (r *optimizerRun[S]) visit(s S) bool {
+	if r.o.memo {
+		if r.seen[s] {
+			r.stats.PrunedSeen++
+			return false
+		}
+		r.seen[s] = true
+	}
+
+	if v := r.o.value(s); v > r.value {
+		r.best, r.value = s, v
+	}
+
+	if r.o.bound != nil && r.o.bound(s) <= r.value {
+		r.stats.PrunedBound++
+		return false
+	}
+
+	if r.o.dominates != nil {
+		key := r.o.domKey(s)
+		others := r.frontier[key]
+		for _, other := range others {
+			if r.o.dominates(other, s) {
+				r.stats.PrunedDominated++
+				return false
+			}
+		}
+		others = slices.DeleteFunc(others, func(other S) bool {
+			return r.o.dominates(s, other)
+		})
+		r.frontier[key] = append(others, s)
+	}
+
+	return true
+}
+
+func (r *optimizerRun[S]) dfs(s S) bool {
+	if r.outOfBudget() {
+		return false
+	}
+	r.stats.Expanded++
+	r.progress.Add(1)
+
+	for _, child := range r.o.children(s) {
+		if r.visit(child) && !r.dfs(child) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *optimizerRun[S]) beam(start S) bool {
+	priority := r.o.value
+	if r.o.bound != nil {
+		priority = r.o.bound
+	}
+
+	level := []S{start}
+	for len(level) > 0 {
+		next := []S{}
+		for _, s := range level {
+			if r.outOfBudget() {
+				return false
+			}
+			r.stats.Expanded++
+			r.progress.Add(1)
+
+			for _, child := range r.o.children(s) {
+				if r.visit(child) {
+					next = append(next, child)
+				}
+			}
+		}
+
+		if len(next) > r.o.beamWidth {
+			slices.SortStableFunc(next, func(a, b S) int {
+				return cmp.Compare(priority(b), priority(a))
+			})
+			r.stats.BeamDropped += len(next) - r.o.beamWidth
+			next = next[:r.o.beamWidth]
+		}
+		level = next
+	}
+	return true
+}
+
+// Run searches from start, returning the best state found.
+func (o *Optimizer[S]) Run(start S) OptimizerResult[S] {
+	r := &optimizerRun[S]{
+		o:        o,
+		best:     start,
+		value:    o.value(start),
+		seen:     map[S]bool{start: true},
+		frontier: map[string][]S{},
+	}
+	if o.timeLimit > 0 {
+		r.deadline = time.Now().Add(o.timeLimit)
+	}
+
+	r.progress = logger.NewProgress("optimizer", "expanded")
+	if o.maxNodes > 0 {
+		r.progress.SetTotal(int64(o.maxNodes))
+	}
+	r.progress.Gauge("best", func() int64 { return int64(r.value) })
+	r.progress.Gauge("pruned", func() int64 {
+		return int64(r.stats.PrunedBound + r.stats.PrunedDominated + r.stats.PrunedSeen)
+	})
+	defer r.progress.Done()
+
+	if o.beamWidth > 0 {
+		r.stats.Complete = r.beam(start) && r.stats.BeamDropped == 0
+	} else {
+		r.stats.Complete = r.dfs(start)
+	}
+
+	return OptimizerResult[S]{Best: r.best, Value: r.value, Stats: r.stats}
+}
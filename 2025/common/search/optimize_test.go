@@ -0,0 +1,189 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+const (
+	ore = iota
+	clay
+	obsidian
+	geode
+)
+
+type blueprint [4][4]int // cost[robot][resource]
+
+type factory struct {
+	timeLeft  int
+	robots    [4]int
+	resources [4]int
+}
+
+func (bp blueprint) children(f factory) []factory {
+	maxUseful := [4]int{}
+	for _, costs := range bp {
+		for res, c := range costs {
+			maxUseful[res] = max(maxUseful[res], c)
+		}
+	}
+
+	out := []factory{}
+	for robot := geode; robot >= ore; robot-- {
+		if robot != geode && f.robots[robot] >= maxUseful[robot] {
+			continue
+		}
+
+		// Wait until we can afford the robot, then build it.
+		wait := 0
+		possible := true
+		for res, c := range bp[robot] {
+			if short := c - f.resources[res]; short > 0 {
+				if f.robots[res] == 0 {
+					possible = false
+					break
+				}
+				wait = max(wait, (short+f.robots[res]-1)/f.robots[res])
+			}
+		}
+		if !possible || wait+1 >= f.timeLeft {
+			continue
+		}
+
+		next := f
+		next.timeLeft -= wait + 1
+		for res := range next.resources {
+			next.resources[res] += (wait+1)*f.robots[res] - bp[robot][res]
+		}
+		next.robots[robot]++
+		out = append(out, next)
+	}
+	return out
+}
+
+// geodes returns the number of geodes f will have if it builds nothing else.
+func geodes(f factory) int {
+	return f.resources[geode] + f.robots[geode]*f.timeLeft
+}
+
+func TestOptimizerGeodes(t *testing.T) {
+	// 2022/19 sample.
+	type TestCase struct {
+		bp   blueprint
+		want int
+	}
+
+	testCases := []TestCase{
+		TestCase{bp: blueprint{{4, 0, 0}, {2, 0, 0}, {3, 14, 0}, {2, 0, 7}}, want: 9},
+		TestCase{bp: blueprint{{2, 0, 0}, {3, 0, 0}, {3, 8, 0}, {3, 0, 12}}, want: 12},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			start := factory{timeLeft: 24, robots: [4]int{1, 0, 0, 0}}
+
+			// Without pruning, as a baseline.
+			o := NewOptimizer(tc.bp.children, geodes)
+			plain := o.Run(start)
+			if plain.Value != tc.want || !plain.Stats.Complete {
+				t.Fatalf("unpruned Run() = %v (complete %v), want %v",
+					plain.Value, plain.Stats.Complete, tc.want)
+			}
+
+			o.SetBound(func(f factory) int {
+				// Build a geode robot every remaining minute.
+				return geodes(f) + f.timeLeft*(f.timeLeft-1)/2
+			})
+			pruned := o.Run(start)
+			if pruned.Value != tc.want || geodes(pruned.Best) != tc.want {
+				t.Errorf("pruned Run() = %v, want %v", pruned.Value, tc.want)
+			}
+			if pruned.Stats.PrunedBound == 0 || pruned.Stats.Expanded >= plain.Stats.Expanded {
+				t.Errorf("bound didn't prune: %+v vs %+v", pruned.Stats, plain.Stats)
+			}
+
+			o.SetMaxNodes(10)
+			if r := o.Run(start); r.Stats.Complete || r.Stats.Expanded > 10 {
+				t.Errorf("Run() with max nodes = %+v, want incomplete", r.Stats)
+			}
+		})
+	}
+}
+
+func TestOptimizerKnapsack(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	type item struct{ weight, value int }
+	items := []item{}
+	for i := 0; i < 16; i++ {
+		items = append(items, item{1 + rng.Intn(20), rng.Intn(50)})
+	}
+	const capacity = 60
+
+	// Brute force.
+	want := 0
+	for mask := 0; mask < 1<<len(items); mask++ {
+		w, v := 0, 0
+		for i, it := range items {
+			if mask&(1<<i) != 0 {
+				w, v = w+it.weight, v+it.value
+			}
+		}
+		if w <= capacity {
+			want = max(want, v)
+		}
+	}
+
+	type state struct{ next, weight, value int }
+	children := func(s state) []state {
+		if s.next == len(items) {
+			return nil
+		}
+		out := []state{{s.next + 1, s.weight, s.value}}
+		if it := items[s.next]; s.weight+it.weight <= capacity {
+			out = append(out, state{s.next + 1, s.weight + it.weight, s.value + it.value})
+		}
+		return out
+	}
+
+	o := NewOptimizer(children, func(s state) int { return s.value })
+	o.SetBound(func(s state) int {
+		v := s.value
+		for _, it := range items[s.next:] {
+			v += it.value
+		}
+		return v
+	})
+	o.SetDominance(func(s state) string { return fmt.Sprint(s.next) },
+		func(a, b state) bool { return a.weight <= b.weight && a.value >= b.value })
+
+	if r := o.Run(state{}); r.Value != want || !r.Stats.Complete {
+		t.Errorf("Run() = %v (stats %+v), want %v", r.Value, r.Stats, want)
+	}
+
+	// A beam as wide as the whole level is exhaustive.
+	o.SetBeamWidth(1 << len(items))
+	if r := o.Run(state{}); r.Value != want || r.Stats.BeamDropped != 0 || !r.Stats.Complete {
+		t.Errorf("wide beam Run() = %v (stats %+v), want %v", r.Value, r.Stats, want)
+	}
+
+	o.SetBeamWidth(1)
+	if r := o.Run(state{}); r.Value > want || r.Stats.Expanded > len(items)+1 || r.Stats.Complete {
+		t.Errorf("narrow beam Run() = %v (stats %+v), want <= %v", r.Value, r.Stats, want)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"github.com/simmonmt/aoc/2025/common/logger"
+	"github.com/simmonmt/aoc/2025/common/mtsmath"
+)
+
+// TimedState is a state at a particular time step.
+type TimedState[S comparable] struct {
+	State S
+	Time  int
+}
+
+// TimedSearch is a breadth-first search through a world that changes over
+// time, where each move (including waiting in place) takes one time step.
+// It finds the earliest time a goal can be reached.
+type TimedSearch[S comparable] struct {
+	neighbors func(s S, t int) []S
+	blocked   func(s S, t int) bool
+	period    int
+	wait      bool
+	maxTime   int
+}
+
+// NewTimedSearch returns a search where neighbors gives the states reachable
+// from s with a move starting at time t (and so ending at t+1). By default
+// waiting is allowed, nothing is blocked, and there's no time limit.
+func NewTimedSearch[S comparable](neighbors func(s S, t int) []S) *TimedSearch[S] {
+	return &TimedSearch[S]{neighbors: neighbors, wait: true, maxTime: -1}
+}
+
+// SetBlocked supplies a function reporting whether s is unusable at time t.
+// Moves (and waits) that would end in a blocked state are discarded.
+func (ts *TimedSearch[S]) SetBlocked(blocked func(s S, t int) bool) {
+	ts.blocked = blocked
+}
+
+// SetPeriod declares that the world repeats every period steps: neighbors
+// and blocked return the same results at t and t+period. This lets the
+// search treat (s, t) and (s, t+period) as the same state, which keeps the
+// state space finite. See GridPeriod.
+func (ts *TimedSearch[S]) SetPeriod(period int) {
+	ts.period = period
+}
+
+// SetWait controls whether waiting in place is allowed.
+func (ts *TimedSearch[S]) SetWait(wait bool) {
+	ts.wait = wait
+}
+
+// SetMaxTime stops the search at the given time. A negative value (the
+// default) means no limit, in which case an unreachable goal will only be
+// detected if a period has been set.
+func (ts *TimedSearch[S]) SetMaxTime(maxTime int) {
+	ts.maxTime = maxTime
+}
+
+// GridPeriod returns the period of a world whose obstacles wrap around a
+// width x height area, like blizzards that move one step at a time.
+func GridPeriod(width, height int) int {
+	return int(mtsmath.LCM(int64(width), int64(height)))
+}
+
+// Run searches from start at time startTime, returning the path (with times)
+// to the earliest-reached state for which goal returns true, or false if none
+// can be reached.
+func (ts *TimedSearch[S]) Run(start S, startTime int, goal func(S) bool) ([]TimedState[S], bool) {
+	type node struct {
+		ts     TimedState[S]
+		parent int
+	}
+
+	key := func(s S, t int) TimedState[S] {
+		if ts.period > 0 {
+			t %= ts.period
+		}
+		return TimedState[S]{State: s, Time: t}
+	}
+
+	nodes := []node{{ts: TimedState[S]{start, startTime}, parent: -1}}
+	seen := map[TimedState[S]]bool{key(start, startTime): true}
+
+	var i int // nodes[i:] is the queue
+	progress := logger.NewProgress("timed search", "expanded")
+	progress.Gauge("time", func() int64 { return int64(nodes[len(nodes)-1].ts.Time) })
+	progress.Gauge("queued", func() int64 { return int64(len(nodes) - i) })
+	defer progress.Done()
+
+	for i = 0; i < len(nodes); i++ {
+		cur := nodes[i].ts
+		progress.Add(1)
+		if goal(cur.State) {
+			path := []TimedState[S]{}
+			for j := i; j >= 0; j = nodes[j].parent {
+				path = append(path, nodes[j].ts)
+			}
+			return reverse(path), true
+		}
+
+		t := cur.Time + 1
+		if ts.maxTime >= 0 && t > ts.maxTime {
+			continue
+		}
+
+		nexts := ts.neighbors(cur.State, cur.Time)
+		if ts.wait {
+			nexts = append(nexts[:len(nexts):len(nexts)], cur.State)
+		}
+		for _, next := range nexts {
+			if ts.blocked != nil && ts.blocked(next, t) {
+				continue
+			}
+			if k := key(next, t); !seen[k] {
+				seen[k] = true
+				nodes = append(nodes, node{ts: TimedState[S]{next, t}, parent: i})
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func reverse[T any](in []T) []T {
+	for i, j := 0, len(in)-1; i < j; i, j = i+1, j-1 {
+		in[i], in[j] = in[j], in[i]
+	}
+	return in
+}
+
+// Periodic returns a memoized version of fn, which must repeat every period
+// steps. Only period values are ever computed, making it a cheap way to
+// precompute moving obstacle layouts.
+func Periodic[T any](period int, fn func(t int) T) func(t int) T {
+	cache := make([]T, period)
+	done := make([]bool, period)
+	return func(t int) T {
+		t = int(mtsmath.Mod(int64(t), int64(period)))
+		if !done[t] {
+			cache[t], done[t] = fn(t), true
+		}
+		return cache[t]
+	}
+}
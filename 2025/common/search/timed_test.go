@@ -0,0 +1,122 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"testing"
+
+	"github.com/simmonmt/aoc/2025/common/dir"
+	"github.com/simmonmt/aoc/2025/common/pos"
+)
+
+func TestTimedSearchBlizzards(t *testing.T) {
+	// 2022/24 sample.
+	lines := []string{
+		"#.######",
+		"#>>.<^<#",
+		"#.<..<<#",
+		"#>v.><>#",
+		"#<^v^^>#",
+		"######.#",
+	}
+	g := mustGrid(t, lines)
+	w, h := g.Width()-2, g.Height()-2 // the interior
+	start, end := pos.P2{X: 1, Y: 0}, pos.P2{X: w, Y: h + 1}
+
+	type blizzard struct {
+		p pos.P2
+		d dir.Dir
+	}
+	blizzards := []blizzard{}
+	g.Walk(func(p pos.P2, r rune) {
+		if d, ok := dir.ParseIcon(r); ok {
+			blizzards = append(blizzards, blizzard{p, d})
+		}
+	})
+
+	period := GridPeriod(w, h)
+	occupied := Periodic(period, func(t int) map[pos.P2]bool {
+		out := map[pos.P2]bool{}
+		for _, b := range blizzards {
+			p := b.d.StepsFrom(b.p, t)
+			p.X = (p.X-1+t*w)%w + 1
+			p.Y = (p.Y-1+t*h)%h + 1
+			out[p] = true
+		}
+		return out
+	})
+
+	ts := NewTimedSearch(func(p pos.P2, t int) []pos.P2 {
+		out := []pos.P2{}
+		for _, n := range g.AllNeighbors(p, false) {
+			if r, _ := g.Get(n); r != '#' {
+				out = append(out, n)
+			}
+		}
+		return out
+	})
+	ts.SetBlocked(func(p pos.P2, t int) bool { return occupied(t)[p] })
+	ts.SetPeriod(period)
+
+	path, ok := ts.Run(start, 0, func(p pos.P2) bool { return p == end })
+	if !ok {
+		t.Fatalf("Run() = _, false, want true")
+	}
+	arrive := path[len(path)-1].Time
+	if arrive != 18 {
+		t.Errorf("arrival time = %v, want 18", arrive)
+	}
+	for i := 1; i < len(path); i++ {
+		if path[i].Time != path[i-1].Time+1 || path[i].State.ManhattanDistance(path[i-1].State) > 1 {
+			t.Errorf("bad step from %v to %v", path[i-1], path[i])
+		}
+		if occupied(path[i].Time)[path[i].State] {
+			t.Errorf("%v is in a blizzard", path[i])
+		}
+	}
+
+	// Back for the snacks, then out again.
+	path, _ = ts.Run(end, arrive, func(p pos.P2) bool { return p == start })
+	path, _ = ts.Run(start, path[len(path)-1].Time, func(p pos.P2) bool { return p == end })
+	if got := path[len(path)-1].Time; got != 54 {
+		t.Errorf("round trip arrival time = %v, want 54", got)
+	}
+
+	// No time to get there.
+	ts.SetMaxTime(17)
+	if _, ok := ts.Run(start, 0, func(p pos.P2) bool { return p == end }); ok {
+		t.Errorf("Run() with max time 17 = _, true, want false")
+	}
+}
+
+func TestTimedSearchNoWait(t *testing.T) {
+	// A one-dimensional corridor whose only gate, at 3, is open on even
+	// time steps.
+	ts := NewTimedSearch(func(x int, t int) []int {
+		return []int{x + 1}
+	})
+	ts.SetBlocked(func(x int, t int) bool { return x == 3 && t%2 == 1 })
+	ts.SetPeriod(2)
+
+	path, ok := ts.Run(0, 0, func(x int) bool { return x == 5 })
+	if !ok || path[len(path)-1].Time != 6 {
+		t.Errorf("Run() with waiting = %v, %v, want arrival at 6", path, ok)
+	}
+
+	ts.SetWait(false)
+	if path, ok := ts.Run(0, 0, func(x int) bool { return x == 5 }); ok {
+		t.Errorf("Run() without waiting = %v, true, want false", path)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+// Match is an occurrence of pattern number Pattern in the text, starting at
+// byte offset Pos.
+type Match struct {
+	Pattern, Pos int
+}
+
+type acNode struct {
+	children map[byte]int
+	fail     int
+	out      []int // patterns ending here, including via fail links
+}
+
+// Matcher finds every occurrence of a set of patterns in a text in a single
+// pass, using the Aho-Corasick algorithm.
+type Matcher struct {
+	patterns []string
+	nodes    []acNode
+}
+
+func NewMatcher(patterns []string) *Matcher {
+	m := &Matcher{
+		patterns: append([]string(nil), patterns...),
+		nodes:    []acNode{{children: map[byte]int{}}},
+	}
+
+	for pi, p := range patterns {
+		cur := 0
+		for i := 0; i < len(p); i++ {
+			next, found := m.nodes[cur].children[p[i]]
+			if !found {
+				next = len(m.nodes)
+				m.nodes = append(m.nodes, acNode{children: map[byte]int{}})
+				m.nodes[cur].children[p[i]] = next
+			}
+			cur = next
+		}
+		m.nodes[cur].out = append(m.nodes[cur].out, pi)
+	}
+
+	// Breadth-first, so each node's fail target is finished before the
+	// node itself.
+	queue := []int{}
+	for _, child := range m.nodes[0].children {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for b, child := range m.nodes[cur].children {
+			m.nodes[child].fail = m.step(m.nodes[cur].fail, b)
+			m.nodes[child].out = append(m.nodes[child].out,
+				m.nodes[m.nodes[child].fail].out...)
+			queue = append(queue, child)
+		}
+	}
+
+	return m
+}
+
+// step returns the state reached from cur on reading b.
+func (m *Matcher) step(cur int, b byte) int {
+	for {
+		if next, found := m.nodes[cur].children[b]; found {
+			return next
+		}
+		if cur == 0 {
+			return 0
+		}
+		cur = m.nodes[cur].fail
+	}
+}
+
+// FindAll returns every occurrence of every pattern in text, including
+// overlapping ones, ordered by end position (and by pattern length, longest
+// first, for matches ending at the same place).
+func (m *Matcher) FindAll(text string) []Match {
+	out := []Match{}
+	m.Scan(text, func(match Match) bool {
+		out = append(out, match)
+		return true
+	})
+	return out
+}
+
+// Scan calls cb for each match, in the same order as FindAll, stopping early
+// if cb returns false.
+func (m *Matcher) Scan(text string, cb func(Match) bool) {
+	cur := 0
+	for i := 0; i < len(text); i++ {
+		cur = m.step(cur, text[i])
+		for _, pi := range m.nodes[cur].out {
+			match := Match{Pattern: pi, Pos: i + 1 - len(m.patterns[pi])}
+			if !cb(match) {
+				return
+			}
+		}
+	}
+}
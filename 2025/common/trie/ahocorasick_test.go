@@ -0,0 +1,71 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatcher(t *testing.T) {
+	m := NewMatcher([]string{"he", "she", "his", "hers"})
+
+	want := []Match{{1, 1}, {0, 2}, {3, 2}}
+	if got := m.FindAll("ushers"); !reflect.DeepEqual(got, want) {
+		t.Errorf(`FindAll("ushers") = %v, want %v`, got, want)
+	}
+
+	if got := m.FindAll("xyz"); len(got) != 0 {
+		t.Errorf(`FindAll("xyz") = %v, want none`, got)
+	}
+
+	num := 0
+	m.Scan("hehehe", func(Match) bool {
+		num++
+		return num < 2
+	})
+	if num != 2 {
+		t.Errorf("Scan didn't stop early; saw %v matches", num)
+	}
+}
+
+func TestSpelledDigits(t *testing.T) {
+	// 2023/01 part 2, where spelled-out digits can overlap.
+	words := []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"1", "2", "3", "4", "5", "6", "7", "8", "9"}
+	m := NewMatcher(words)
+
+	calibrate := func(line string) int {
+		matches := m.FindAll(line)
+		digit := func(match Match) int { return match.Pattern%9 + 1 }
+		return 10*digit(matches[0]) + digit(matches[len(matches)-1])
+	}
+
+	lines := map[string]int{
+		"two1nine":         29,
+		"eightwothree":     83,
+		"abcone2threexyz":  13,
+		"xtwone3four":      24,
+		"4nineeightseven2": 42,
+		"zoneight234":      14,
+		"7pqrstsixteen":    76,
+		"oneight":          18,
+	}
+	for line, want := range lines {
+		if got := calibrate(line); got != want {
+			t.Errorf("calibrate(%v) = %v, want %v", line, got, want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trie implements string prefix trees and Aho-Corasick multi-pattern
+// matching.
+package trie
+
+import (
+	"iter"
+	"slices"
+)
+
+type node[V any] struct {
+	children map[byte]*node[V]
+	val      V
+	hasVal   bool
+}
+
+func newNode[V any]() *node[V] {
+	return &node[V]{children: map[byte]*node[V]{}}
+}
+
+// Trie maps strings to values, supporting lookups by prefix.
+type Trie[V any] struct {
+	root *node[V]
+	len  int
+}
+
+func New[V any]() *Trie[V] {
+	return &Trie[V]{root: newNode[V]()}
+}
+
+func (t *Trie[V]) Len() int {
+	return t.len
+}
+
+// Insert associates key with val, replacing any previous value.
+func (t *Trie[V]) Insert(key string, val V) {
+	n := t.root
+	for i := 0; i < len(key); i++ {
+		child, found := n.children[key[i]]
+		if !found {
+			child = newNode[V]()
+			n.children[key[i]] = child
+		}
+		n = child
+	}
+
+	if !n.hasVal {
+		t.len++
+	}
+	n.val, n.hasVal = val, true
+}
+
+func (t *Trie[V]) find(key string) *node[V] {
+	n := t.root
+	for i := 0; i < len(key) && n != nil; i++ {
+		n = n.children[key[i]]
+	}
+	return n
+}
+
+func (t *Trie[V]) Get(key string) (V, bool) {
+	if n := t.find(key); n != nil && n.hasVal {
+		return n.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Prefixes iterates over every key that's a prefix of s, shortest first,
+// yielding each key's length and value. This is the inner loop of most
+// prefix-matching DPs: s[length:] is what's left after the key.
+func (t *Trie[V]) Prefixes(s string) iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		n := t.root
+		for i := 0; ; i++ {
+			if n.hasVal && !yield(i, n.val) {
+				return
+			}
+			if i == len(s) {
+				return
+			}
+			if n = n.children[s[i]]; n == nil {
+				return
+			}
+		}
+	}
+}
+
+// WithPrefix iterates over every key that starts with prefix, in
+// lexicographic order.
+func (t *Trie[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n := t.find(prefix)
+		if n == nil {
+			return
+		}
+
+		var walk func(n *node[V], key []byte) bool
+		walk = func(n *node[V], key []byte) bool {
+			if n.hasVal && !yield(string(key), n.val) {
+				return false
+			}
+
+			edges := make([]byte, 0, len(n.children))
+			for b := range n.children {
+				edges = append(edges, b)
+			}
+			slices.Sort(edges)
+
+			for _, b := range edges {
+				if !walk(n.children[b], append(key, b)) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(n, []byte(prefix))
+	}
+}
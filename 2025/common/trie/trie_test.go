@@ -0,0 +1,89 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	tr := New[int]()
+	for i, k := range []string{"b", "br", "bwu", "g", "gb", "r", "rb", "wr"} {
+		tr.Insert(k, i)
+	}
+	tr.Insert("br", 99)
+
+	if got := tr.Len(); got != 8 {
+		t.Errorf("Len() = %v, want 8", got)
+	}
+	if v, found := tr.Get("br"); !found || v != 99 {
+		t.Errorf(`Get("br") = %v, %v, want 99, true`, v, found)
+	}
+	if _, found := tr.Get("bw"); found {
+		t.Errorf(`Get("bw") = _, true, want false`)
+	}
+
+	type prefix struct {
+		l, v int
+	}
+	got := []prefix{}
+	for l, v := range tr.Prefixes("brwrr") {
+		got = append(got, prefix{l, v})
+	}
+	if want := []prefix{{1, 0}, {2, 99}}; !reflect.DeepEqual(got, want) {
+		t.Errorf(`Prefixes("brwrr") = %v, want %v`, got, want)
+	}
+
+	keys := []string{}
+	for k := range tr.WithPrefix("b") {
+		keys = append(keys, k)
+	}
+	if want := []string{"b", "br", "bwu"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf(`WithPrefix("b") = %v, want %v`, keys, want)
+	}
+}
+
+func TestTowelDesigns(t *testing.T) {
+	// 2024/19 sample, as a linear-time prefix DP.
+	tr := New[bool]()
+	for _, towel := range []string{"r", "wr", "b", "g", "bwu", "rb", "gb", "br"} {
+		tr.Insert(towel, true)
+	}
+
+	countWays := func(design string) int {
+		ways := make([]int, len(design)+1)
+		ways[0] = 1
+		for i := range design {
+			if ways[i] == 0 {
+				continue
+			}
+			for l := range tr.Prefixes(design[i:]) {
+				ways[i+l] += ways[i]
+			}
+		}
+		return ways[len(design)]
+	}
+
+	designs := map[string]int{
+		"brwrr": 2, "bggr": 1, "gbbr": 4, "rrbgbr": 6,
+		"ubwu": 0, "bwurrg": 1, "brgr": 2, "bbrwb": 0,
+	}
+	for design, want := range designs {
+		if got := countWays(design); got != want {
+			t.Errorf("countWays(%v) = %v, want %v", design, got, want)
+		}
+	}
+}
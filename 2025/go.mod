@@ -1,10 +1,9 @@
 module github.com/simmonmt/aoc/2025
 
-go 1.23
-
-toolchain go1.23.2
+go 1.24.0
 
 require (
 	github.com/google/go-cmp v0.6.0
 	golang.org/x/exp v0.0.0-20241108190413-2d47ceb2692f
+	golang.org/x/tools v0.39.0
 )